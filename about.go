@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/backend"
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// AboutLink is a clickable link in the about dialog
+type AboutLink struct {
+	Label string
+	URL   string
+}
+
+// AppInfo describes the application for AboutDialog
+type AppInfo struct {
+	Name    string
+	Version string
+	License string
+	Credits []string
+	Icon    *backend.Texture
+	Links   []AboutLink
+}
+
+// aboutState records a pending request to open the dialog
+type aboutState struct {
+	open bool
+}
+
+func (s *aboutState) Dispose() {
+	// Nothing to clean up
+}
+
+// AboutDialogWidget is a modal showing the application's name, version,
+// license, credits and links, with a button copying diagnostics for bug
+// reports
+type AboutDialogWidget struct {
+	id   string
+	info AppInfo
+}
+
+// AboutDialog creates the about modal; it stays hidden until Open is called
+func AboutDialog(info AppInfo) *AboutDialogWidget {
+	return &AboutDialogWidget{
+		id:   fmt.Sprintf("About %s##about", info.Name),
+		info: info,
+	}
+}
+
+// Open shows the dialog on the next frame
+func (a *AboutDialogWidget) Open() {
+	a.getState().open = true
+}
+
+func (a *AboutDialogWidget) getState() *aboutState {
+	if existingState, exists := GlobalContext.stateMap[a.id]; exists {
+		if state, ok := existingState.(*aboutState); ok {
+			return state
+		}
+	}
+
+	newState := &aboutState{}
+	GlobalContext.stateMap[a.id] = newState
+	return newState
+}
+
+// Diagnostics returns a plain-text summary of the application and the
+// environment it runs in, for pasting into bug reports
+func (a *AboutDialogWidget) Diagnostics() string {
+	return fmt.Sprintf("%s %s\n%s", a.info.Name, a.info.Version, environmentDiagnostics())
+}
+
+// environmentDiagnostics describes the OS, toolchain, library versions and
+// display, one "Name: value" per line
+func environmentDiagnostics() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "OS: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "Go: %s\n", runtime.Version())
+	fmt.Fprintf(&b, "Dear ImGui: %s\n", imgui.Version())
+	fmt.Fprintf(&b, "cimgui-go: %s\n", moduleVersion("github.com/AllenDang/cimgui-go"))
+
+	io := imgui.CurrentIO()
+	fmt.Fprintf(&b, "Platform backend: %s\n", io.BackendPlatformName())
+	fmt.Fprintf(&b, "Renderer backend: %s\n", io.BackendRendererName())
+	fmt.Fprintf(&b, "Display: %.0fx%.0f @ %.2fx\n", io.DisplaySize().X, io.DisplaySize().Y, imgui.MainViewport().DpiScale())
+	return b.String()
+}
+
+// moduleVersion returns the version of a dependency compiled into the binary
+func moduleVersion(path string) string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == path {
+			return dep.Version
+		}
+	}
+	return "unknown"
+}
+
+func (a *AboutDialogWidget) Build() {
+	state := a.getState()
+	if state.open {
+		imgui.OpenPopupStr(a.id)
+		state.open = false
+	}
+
+	center := imgui.MainViewport().Center()
+	imgui.SetNextWindowPosV(center, imgui.CondAppearing, imgui.Vec2{X: 0.5, Y: 0.5})
+	if !imgui.BeginPopupModalV(a.id, nil, imgui.WindowFlagsAlwaysAutoResize|imgui.WindowFlagsNoSavedSettings) {
+		return
+	}
+	defer imgui.EndPopup()
+
+	if a.info.Icon != nil {
+		Avatar(a.info.Icon).Size(64).Build()
+		imgui.SameLine()
+	}
+	imgui.BeginGroup()
+	imgui.Text(a.info.Name)
+	if a.info.Version != "" {
+		imgui.TextDisabled(fmt.Sprintf("Version %s", a.info.Version))
+	}
+	if a.info.License != "" {
+		imgui.TextDisabled(a.info.License)
+	}
+	imgui.EndGroup()
+
+	for _, link := range a.info.Links {
+		imgui.TextLinkOpenURLV(link.Label, link.URL)
+		imgui.SetItemTooltip(link.URL)
+	}
+
+	if len(a.info.Credits) > 0 {
+		imgui.SeparatorText("Credits")
+		for _, credit := range a.info.Credits {
+			imgui.BulletText(credit)
+		}
+	}
+
+	imgui.Separator()
+	if imgui.Button("Copy Diagnostics") {
+		imgui.SetClipboardText(a.Diagnostics())
+		Announce("Diagnostics copied to the clipboard", AnnouncePolite)
+	}
+	imgui.SameLine()
+	if imgui.Button("Close") || imgui.IsKeyPressedBool(imgui.KeyEscape) {
+		imgui.CloseCurrentPopup()
+	}
+	imgui.SetItemDefaultFocus()
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Side places a floating window next to an item
+type Side int
+
+const (
+	SideBelow Side = iota
+	SideAbove
+	SideRight
+	SideLeft
+)
+
+// Corner places a floating window in a corner of the screen
+type Corner int
+
+const (
+	CornerTopLeft Corner = iota
+	CornerTopRight
+	CornerBottomLeft
+	CornerBottomRight
+)
+
+// Anchor positions popups, tooltips and toasts relative to an item or to
+// a screen corner instead of the mouse cursor
+type Anchor struct {
+	toScreen bool
+	side     Side
+	corner   Corner
+	offset   imgui.Vec2
+	noFlip   bool
+}
+
+// AnchorItem places the window on the given side of the item it belongs to
+func AnchorItem(side Side) Anchor {
+	return Anchor{side: side}
+}
+
+// AnchorScreen places the window in a corner of the main viewport
+func AnchorScreen(corner Corner) Anchor {
+	return Anchor{toScreen: true, corner: corner}
+}
+
+// Offset moves the window away from the anchor point; for screen corners it
+// is the margin from both edges
+func (a Anchor) Offset(x, y float32) Anchor {
+	a.offset = imgui.Vec2{X: x, Y: y}
+	return a
+}
+
+// NoFlip keeps the window on its side even when it does not fit there
+func (a Anchor) NoFlip() Anchor {
+	a.noFlip = true
+	return a
+}
+
+// place sets the next window's position. itemMin and itemMax are the
+// anchoring item's bounds; size is the window size, usually from the last
+// frame, and may be zero before the window was first shown.
+func (a Anchor) place(itemMin, itemMax, size imgui.Vec2) {
+	viewport := imgui.MainViewport()
+	workMin := viewport.WorkPos()
+	workMax := workMin.Add(viewport.WorkSize())
+
+	if a.toScreen {
+		pos, pivot := workMin.Add(a.offset), imgui.Vec2{}
+		if a.corner == CornerTopRight || a.corner == CornerBottomRight {
+			pos.X, pivot.X = workMax.X-a.offset.X, 1
+		}
+		if a.corner == CornerBottomLeft || a.corner == CornerBottomRight {
+			pos.Y, pivot.Y = workMax.Y-a.offset.Y, 1
+		}
+		imgui.SetNextWindowPosV(pos, imgui.CondAlways, pivot)
+		return
+	}
+
+	var pos imgui.Vec2
+	switch a.side {
+	case SideBelow, SideAbove:
+		pos.X = itemMin.X + a.offset.X
+		below := itemMax.Y + a.offset.Y
+		above := itemMin.Y - a.offset.Y - size.Y
+		pos.Y = below
+		if a.side == SideAbove {
+			pos.Y = above
+		}
+		if !a.noFlip {
+			if a.side == SideBelow && below+size.Y > workMax.Y && above >= workMin.Y {
+				pos.Y = above
+			} else if a.side == SideAbove && above < workMin.Y && below+size.Y <= workMax.Y {
+				pos.Y = below
+			}
+		}
+	case SideRight, SideLeft:
+		pos.Y = itemMin.Y + a.offset.Y
+		right := itemMax.X + a.offset.X
+		left := itemMin.X - a.offset.X - size.X
+		pos.X = right
+		if a.side == SideLeft {
+			pos.X = left
+		}
+		if !a.noFlip {
+			if a.side == SideRight && right+size.X > workMax.X && left >= workMin.X {
+				pos.X = left
+			} else if a.side == SideLeft && left < workMin.X && right+size.X <= workMax.X {
+				pos.X = right
+			}
+		}
+	}
+
+	// Slide along the edge so the window stays on screen
+	pos.X = max(workMin.X, min(pos.X, workMax.X-size.X))
+	pos.Y = max(workMin.Y, min(pos.Y, workMax.Y-size.Y))
+	imgui.SetNextWindowPosV(pos, imgui.CondAlways, imgui.Vec2{})
+}
+
+// anchoredSizes remembers the size of anchored windows from the last frame,
+// needed to flip and clamp them before they are drawn
+var anchoredSizes = make(map[string]imgui.Vec2)
+
+// Anchor positions the tooltip relative to the hovered item instead of the
+// mouse (builder pattern)
+func (t *TooltipWidget) Anchor(anchor Anchor) *TooltipWidget {
+	t.anchor = &anchor
+	return t
+}
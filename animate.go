@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Animation describes how a widget enters or leaves: fading its alpha,
+// sliding it horizontally, or both
+type Animation struct {
+	Duration time.Duration
+	Fade     bool
+	SlideX   float32
+}
+
+// Predefined animations; use Over to change the duration
+var (
+	FadeIn  = Animation{Duration: 200 * time.Millisecond, Fade: true}
+	FadeOut = Animation{Duration: 200 * time.Millisecond, Fade: true}
+)
+
+// Slide moves the widget in from (or out to) distance pixels to the side
+func Slide(distance float32) Animation {
+	return Animation{Duration: 200 * time.Millisecond, SlideX: distance}
+}
+
+// Over returns the animation with a different duration
+func (a Animation) Over(duration time.Duration) Animation {
+	a.Duration = duration
+	return a
+}
+
+// animatedState tracks how far the widget is shown, from 0 (hidden) to 1
+type animatedState struct {
+	amount    float32
+	lastFrame int32
+}
+
+func (s *animatedState) Dispose() {
+	// Nothing to clean up
+}
+
+// AnimatedWidget animates a widget when it starts being built and when it
+// is hidden with Visible(false)
+type AnimatedWidget struct {
+	id       string
+	widget   Widget
+	onAppear *Animation
+	onRemove *Animation
+	visible  bool
+}
+
+// Animated wraps a widget so it can animate in and out. To animate removal,
+// keep building the wrapper with Visible(false); it stops drawing the
+// widget once the animation ends.
+func Animated(id string, widget Widget) *AnimatedWidget {
+	return &AnimatedWidget{
+		id:      fmt.Sprintf("##animated_%s", id),
+		widget:  widget,
+		visible: true,
+	}
+}
+
+// AnimateOnAppear sets the animation played when the widget appears (builder pattern)
+func (a *AnimatedWidget) AnimateOnAppear(animation Animation) *AnimatedWidget {
+	a.onAppear = &animation
+	return a
+}
+
+// AnimateOnRemove sets the animation played when the widget is hidden (builder pattern)
+func (a *AnimatedWidget) AnimateOnRemove(animation Animation) *AnimatedWidget {
+	a.onRemove = &animation
+	return a
+}
+
+// Visible shows or hides the widget, animating the change (builder pattern)
+func (a *AnimatedWidget) Visible(visible bool) *AnimatedWidget {
+	a.visible = visible
+	return a
+}
+
+// IsAnimating reports whether an appear or remove animation is in progress
+func (a *AnimatedWidget) IsAnimating() bool {
+	amount := a.getState().amount
+	return amount > 0 && amount < 1
+}
+
+func (a *AnimatedWidget) getState() *animatedState {
+	if existingState, exists := GlobalContext.stateMap[a.id]; exists {
+		if state, ok := existingState.(*animatedState); ok {
+			return state
+		}
+	}
+
+	newState := &animatedState{lastFrame: -1}
+	GlobalContext.stateMap[a.id] = newState
+	return newState
+}
+
+// stepAnimation advances amount toward target over the animation's duration; a nil
+// animation jumps straight to the target
+func stepAnimation(amount, target float32, animation *Animation) float32 {
+	if animation == nil || animation.Duration <= 0 {
+		return target
+	}
+
+	delta := imgui.CurrentIO().DeltaTime() / float32(animation.Duration.Seconds())
+	if amount < target {
+		return min(amount+delta, target)
+	}
+	return max(amount-delta, target)
+}
+
+// easeOutCubic starts fast and settles gently
+func easeOutCubic(t float32) float32 {
+	inverse := 1 - t
+	return 1 - inverse*inverse*inverse
+}
+
+func (a *AnimatedWidget) Build() {
+	state := a.getState()
+
+	// A widget that was not built last frame is appearing again
+	frame := imgui.FrameCount()
+	if state.lastFrame != frame-1 {
+		state.amount = 0
+		if !a.visible {
+			state.lastFrame = frame
+			return
+		}
+	}
+	state.lastFrame = frame
+
+	animation := a.onAppear
+	if a.visible {
+		state.amount = stepAnimation(state.amount, 1, a.onAppear)
+	} else {
+		animation = a.onRemove
+		state.amount = stepAnimation(state.amount, 0, a.onRemove)
+	}
+
+	if state.amount <= 0 {
+		return
+	}
+	if state.amount >= 1 || animation == nil {
+		buildWidget(a.widget)
+		return
+	}
+
+	eased := easeOutCubic(state.amount)
+	if animation.SlideX != 0 {
+		// The group indents every line of the widget, not just the first
+		imgui.SetCursorPosX(imgui.CursorPosX() + (1-eased)*animation.SlideX)
+		imgui.BeginGroup()
+		defer imgui.EndGroup()
+	}
+	if animation.Fade {
+		PushStyleVar(imgui.StyleVarAlpha, imgui.CurrentStyle().Alpha()*eased)
+		defer PopStyleVar(1)
+	}
+
+	buildWidget(a.widget)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Politeness says how urgently an announcement interrupts the user
+type Politeness int
+
+const (
+	AnnouncePolite    Politeness = iota // waits until the user is idle
+	AnnounceAssertive                   // interrupts immediately
+)
+
+// Announcer delivers an announcement to assistive technology, returning
+// false if it could not
+type Announcer func(text string, politeness Politeness) bool
+
+// toast is an announcement shown on screen
+type toast struct {
+	text       string
+	politeness Politeness
+	expires    time.Time
+}
+
+// Announcement state
+var (
+	announcer Announcer
+	toasts    []toast
+)
+
+// maxToasts is how many announcements are on screen at once
+const maxToasts = 4
+
+// SetAnnouncer installs the bridge to the platform's accessibility layer.
+// The backend has none of its own, so without one announcements are shown
+// as toasts.
+func SetAnnouncer(a Announcer) {
+	announcer = a
+}
+
+// Announce conveys a dynamic change such as "3 results found" to the
+// user. It goes to the installed Announcer, falling back to a toast in the
+// bottom-right corner.
+func Announce(text string, politeness Politeness) {
+	if announcer != nil && announcer(text, politeness) {
+		return
+	}
+
+	duration := 4 * time.Second
+	if politeness == AnnounceAssertive {
+		duration = 6 * time.Second
+	}
+	toasts = append(toasts, toast{text: text, politeness: politeness, expires: time.Now().Add(duration)})
+	if len(toasts) > maxToasts {
+		toasts = toasts[len(toasts)-maxToasts:]
+	}
+
+	if !HasOverlay("announcements") {
+		AddOverlay("announcements", &toastStackWidget{}).
+			Order(1000).
+			Anchor(AnchorScreen(CornerBottomRight).Offset(-12, -12))
+	}
+}
+
+// toastStackWidget draws the live toasts and removes its overlay once the
+// last one expires
+type toastStackWidget struct{}
+
+func (t *toastStackWidget) Build() {
+	now := time.Now()
+	live := toasts[:0]
+	for _, entry := range toasts {
+		if now.Before(entry.expires) {
+			live = append(live, entry)
+		}
+	}
+	toasts = live
+	if len(toasts) == 0 {
+		RemoveOverlay("announcements")
+		return
+	}
+
+	padding := imgui.CurrentStyle().WindowPadding()
+	for _, entry := range toasts {
+		accent := imgui.ColorU32Col(imgui.ColCheckMark)
+		if entry.politeness == AnnounceAssertive {
+			accent = imgui.ColorU32Vec4(imgui.Vec4{X: 0.95, Y: 0.6, Z: 0.2, W: 1})
+		}
+
+		// Fade out over the last half second
+		alpha := float32(min(1, entry.expires.Sub(now).Seconds()/0.5))
+		size := imgui.CalcTextSize(entry.text).Add(padding.Mul(2))
+		pos := imgui.CursorScreenPos()
+		imgui.Dummy(size)
+
+		drawList := imgui.WindowDrawList()
+		background := imgui.Vec4{X: 0.1, Y: 0.1, Z: 0.12, W: 0.92 * alpha}
+		drawList.AddRectFilledV(pos, pos.Add(size), imgui.ColorU32Vec4(background), 4, imgui.DrawFlagsRoundCornersAll)
+		drawList.AddRectFilled(pos, imgui.Vec2{X: pos.X + 3, Y: pos.Y + size.Y}, accent)
+		drawList.AddTextVec2(pos.Add(padding), imgui.ColorU32Vec4(imgui.Vec4{X: 1, Y: 1, Z: 1, W: alpha}), entry.text)
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// BarcodeFormat is a 1D barcode symbology
+type BarcodeFormat int
+
+const (
+	BarcodeCode128 BarcodeFormat = iota // any printable ASCII
+	BarcodeEAN13                        // 12 digits plus a check digit
+)
+
+// code128Patterns are the bar and space widths of each Code 128 symbol,
+// starting with a bar; 104 is Start B and 106 is Stop
+var code128Patterns = []string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+// encodeCode128 encodes printable ASCII with code set B
+func encodeCode128(data string) ([]bool, error) {
+	const startB, stop = 104, 106
+
+	symbols := []int{startB}
+	checksum := startB
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if c < 32 || c > 126 {
+			return nil, fmt.Errorf("code 128 cannot encode byte %#x", c)
+		}
+		symbols = append(symbols, int(c)-32)
+		checksum += (int(c) - 32) * (i + 1)
+	}
+	symbols = append(symbols, checksum%103, stop)
+
+	var modules []bool
+	for _, symbol := range symbols {
+		for i, width := range code128Patterns[symbol] {
+			for w := 0; w < int(width-'0'); w++ {
+				modules = append(modules, i%2 == 0)
+			}
+		}
+	}
+	return modules, nil
+}
+
+// ean13Patterns are the left-hand odd parity (L) digit encodings; G codes
+// are the mirrored complement and R codes the complement
+var ean13Patterns = [10]string{
+	"0001101", "0011001", "0010011", "0111101", "0100011",
+	"0110001", "0101111", "0111011", "0110111", "0001011",
+}
+
+// ean13Parity selects L (false) or G (true) for the six left digits,
+// indexed by the first digit
+var ean13Parity = [10]string{
+	"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG",
+	"LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL",
+}
+
+// encodeEAN13 encodes 12 digits, adding the check digit, or 13 digits with
+// a valid check digit
+func encodeEAN13(data string) ([]bool, error) {
+	if len(data) != 12 && len(data) != 13 {
+		return nil, fmt.Errorf("EAN-13 needs 12 or 13 digits, got %d characters", len(data))
+	}
+	digits := make([]int, 0, 13)
+	for _, c := range data {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("EAN-13 cannot encode %q", c)
+		}
+		digits = append(digits, int(c-'0'))
+	}
+
+	sum := 0
+	for i, digit := range digits[:12] {
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	check := (10 - sum%10) % 10
+	if len(digits) == 13 && digits[12] != check {
+		return nil, fmt.Errorf("EAN-13 check digit is %d, expected %d", digits[12], check)
+	}
+	digits = append(digits[:12], check)
+
+	var modules []bool
+	appendBits := func(bits string, invert, reverse bool) {
+		for i := range bits {
+			if reverse {
+				i = len(bits) - 1 - i
+			}
+			modules = append(modules, (bits[i] == '1') != invert)
+		}
+	}
+
+	appendBits("101", false, false)
+	parity := ean13Parity[digits[0]]
+	for i, digit := range digits[1:7] {
+		if parity[i] == 'G' {
+			appendBits(ean13Patterns[digit], true, true)
+		} else {
+			appendBits(ean13Patterns[digit], false, false)
+		}
+	}
+	appendBits("01010", false, false)
+	for _, digit := range digits[7:] {
+		appendBits(ean13Patterns[digit], true, false)
+	}
+	appendBits("101", false, false)
+	return modules, nil
+}
+
+// BarcodeWidget draws a 1D barcode with the draw list
+type BarcodeWidget struct {
+	data     string
+	format   BarcodeFormat
+	width    float32
+	height   float32
+	showText bool
+}
+
+// Barcode creates a Code 128 barcode for data
+func Barcode(data string) *BarcodeWidget {
+	return &BarcodeWidget{data: data, height: 60, showText: true}
+}
+
+// Format sets the symbology (builder pattern)
+func (b *BarcodeWidget) Format(format BarcodeFormat) *BarcodeWidget {
+	b.format = format
+	return b
+}
+
+// Size sets the bar area size; zero width uses two pixels per module (builder pattern)
+func (b *BarcodeWidget) Size(width, height float32) *BarcodeWidget {
+	b.width = width
+	b.height = height
+	return b
+}
+
+// ShowText toggles the human-readable text below the bars (builder pattern)
+func (b *BarcodeWidget) ShowText(show bool) *BarcodeWidget {
+	b.showText = show
+	return b
+}
+
+func (b *BarcodeWidget) Build() {
+	var modules []bool
+	var err error
+	switch b.format {
+	case BarcodeEAN13:
+		modules, err = encodeEAN13(b.data)
+	default:
+		modules, err = encodeCode128(b.data)
+	}
+	if err != nil {
+		ReportDiagnostic(err.Error())
+		return
+	}
+
+	// Ten modules of quiet zone on each side
+	const quietZone = 10
+	count := len(modules) + 2*quietZone
+	module := float32(2)
+	if b.width > 0 {
+		module = b.width / float32(count)
+	}
+	size := imgui.Vec2{X: module * float32(count), Y: b.height}
+	if b.showText {
+		size.Y += imgui.TextLineHeight() + 2
+	}
+
+	pos := imgui.CursorScreenPos()
+	imgui.Dummy(size)
+
+	drawList := imgui.WindowDrawList()
+	drawList.AddRectFilled(pos, pos.Add(size), imgui.ColorU32Vec4(imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1}))
+	black := imgui.ColorU32Vec4(imgui.Vec4{X: 0, Y: 0, Z: 0, W: 1})
+	for i := 0; i < len(modules); {
+		if !modules[i] {
+			i++
+			continue
+		}
+		// Draw each bar as one rectangle
+		start := i
+		for i < len(modules) && modules[i] {
+			i++
+		}
+		x := pos.X + float32(start+quietZone)*module
+		drawList.AddRectFilled(imgui.Vec2{X: x, Y: pos.Y}, imgui.Vec2{X: x + float32(i-start)*module, Y: pos.Y + b.height}, black)
+	}
+
+	if b.showText {
+		textSize := imgui.CalcTextSize(b.data)
+		drawList.AddTextVec2(imgui.Vec2{X: pos.X + (size.X-textSize.X)/2, Y: pos.Y + b.height + 1}, black, b.data)
+	}
+}
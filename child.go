@@ -0,0 +1,92 @@
+package main
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// ChildWidget is a scrollable region inside the window; content that
+// does not fit scrolls instead of growing the window
+type ChildWidget struct {
+	id         string
+	width      float32
+	height     float32
+	border     bool
+	childFlags imgui.ChildFlags
+	flags      imgui.WindowFlags
+	overflow   Overflow
+	widgets    []Widget
+	theme      *Theme
+}
+
+// Child creates a region filling the available space
+func Child() *ChildWidget {
+	return &ChildWidget{id: "##child", overflow: OverflowScroll}
+}
+
+// ID sets the region's ID; give sibling regions distinct IDs (builder pattern)
+func (c *ChildWidget) ID(id string) *ChildWidget {
+	c.id = "##child_" + id
+	return c
+}
+
+// Border draws a frame around the region (builder pattern)
+func (c *ChildWidget) Border(border bool) *ChildWidget {
+	c.border = border
+	return c
+}
+
+// Size sets the region's size. Zero fills the available space, a negative
+// value fills it except for that many pixels (builder pattern)
+func (c *ChildWidget) Size(width, height float32) *ChildWidget {
+	c.width = width
+	c.height = height
+	return c
+}
+
+// ChildFlags sets imgui child flags such as auto-resizing (builder pattern)
+func (c *ChildWidget) ChildFlags(flags imgui.ChildFlags) *ChildWidget {
+	c.childFlags = flags
+	return c
+}
+
+// Flags sets the imgui window flags of the region, e.g. a horizontal
+// scrollbar (builder pattern)
+func (c *ChildWidget) Flags(flags imgui.WindowFlags) *ChildWidget {
+	c.flags = flags
+	return c
+}
+
+// Layout sets the region's contents (builder pattern)
+func (c *ChildWidget) Layout(widgets ...Widget) *ChildWidget {
+	c.widgets = widgets
+	return c
+}
+
+func (c *ChildWidget) Build() {
+	childFlags := c.childFlags
+	if c.border {
+		childFlags |= imgui.ChildFlagsBorders
+	}
+	flags := c.flags
+	switch c.overflow {
+	case OverflowHidden:
+		flags |= imgui.WindowFlagsNoScrollbar | imgui.WindowFlagsNoScrollWithMouse
+	case OverflowVisible:
+		childFlags |= imgui.ChildFlagsAutoResizeX | imgui.ChildFlagsAutoResizeY
+	}
+
+	// The background is read at BeginChild, so the theme wraps the whole region
+	var scope themeScope
+	if c.theme != nil {
+		scope = c.theme.push()
+	}
+
+	// EndChild is needed even when the region is clipped away
+	if imgui.BeginChildStrV(c.id, imgui.Vec2{X: c.width, Y: c.height}, childFlags, flags) {
+		for _, widget := range c.widgets {
+			buildWidget(widget)
+		}
+	}
+	imgui.EndChild()
+	scope.pop()
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// HSV creates an opaque color from hue, saturation and value, all in [0, 1]
+func HSV(h, s, v float32) imgui.Vec4 {
+	return HSVA(h, s, v, 1.0)
+}
+
+// HSVA creates a color from hue, saturation, value and alpha, all in [0, 1]
+func HSVA(h, s, v, alpha float32) imgui.Vec4 {
+	h = wrapHue(h)
+	s = clamp01(s)
+	v = clamp01(v)
+
+	sector := h * 6
+	i := int(sector)
+	f := sector - float32(i)
+	p := v * (1 - s)
+	q := v * (1 - s*f)
+	t := v * (1 - s*(1-f))
+
+	switch i % 6 {
+	case 0:
+		return imgui.Vec4{X: v, Y: t, Z: p, W: alpha}
+	case 1:
+		return imgui.Vec4{X: q, Y: v, Z: p, W: alpha}
+	case 2:
+		return imgui.Vec4{X: p, Y: v, Z: t, W: alpha}
+	case 3:
+		return imgui.Vec4{X: p, Y: q, Z: v, W: alpha}
+	case 4:
+		return imgui.Vec4{X: t, Y: p, Z: v, W: alpha}
+	default:
+		return imgui.Vec4{X: v, Y: p, Z: q, W: alpha}
+	}
+}
+
+// ColorToHSV converts a color to hue, saturation and value, all in [0, 1]
+func ColorToHSV(c imgui.Vec4) (h, s, v float32) {
+	max, min := maxComponent(c), minComponent(c)
+	delta := max - min
+
+	v = max
+	if max > 0 {
+		s = delta / max
+	}
+	return hue(c, max, delta), s, v
+}
+
+// HSL creates an opaque color from hue, saturation and lightness, all in [0, 1]
+func HSL(h, s, l float32) imgui.Vec4 {
+	return HSLA(h, s, l, 1.0)
+}
+
+// HSLA creates a color from hue, saturation, lightness and alpha, all in [0, 1]
+func HSLA(h, s, l, alpha float32) imgui.Vec4 {
+	s = clamp01(s)
+	l = clamp01(l)
+
+	// HSL maps onto HSV with a different saturation and brightness
+	v := l + s*float32(math.Min(float64(l), float64(1-l)))
+	sv := float32(0)
+	if v > 0 {
+		sv = 2 * (1 - l/v)
+	}
+	return HSVA(h, sv, v, alpha)
+}
+
+// ColorToHSL converts a color to hue, saturation and lightness, all in [0, 1]
+func ColorToHSL(c imgui.Vec4) (h, s, l float32) {
+	max, min := maxComponent(c), minComponent(c)
+	delta := max - min
+
+	l = (max + min) / 2
+	if delta > 0 {
+		s = delta / (1 - float32(math.Abs(float64(2*l-1))))
+	}
+	return hue(c, max, delta), s, l
+}
+
+// Lighten raises a color's lightness by amount (0-1)
+func Lighten(c imgui.Vec4, amount float32) imgui.Vec4 {
+	h, s, l := ColorToHSL(c)
+	return HSLA(h, s, l+amount, c.W)
+}
+
+// Darken lowers a color's lightness by amount (0-1)
+func Darken(c imgui.Vec4, amount float32) imgui.Vec4 {
+	return Lighten(c, -amount)
+}
+
+// WithAlpha returns the color with its alpha replaced
+func WithAlpha(c imgui.Vec4, alpha float32) imgui.Vec4 {
+	c.W = alpha
+	return c
+}
+
+// Mix linearly interpolates between two colors; t=0 gives a, t=1 gives b
+func Mix(a, b imgui.Vec4, t float32) imgui.Vec4 {
+	t = clamp01(t)
+	return imgui.Vec4{
+		X: a.X + (b.X-a.X)*t,
+		Y: a.Y + (b.Y-a.Y)*t,
+		Z: a.Z + (b.Z-a.Z)*t,
+		W: a.W + (b.W-a.W)*t,
+	}
+}
+
+// Luminance returns the WCAG relative luminance of a color
+func Luminance(c imgui.Vec4) float32 {
+	linear := func(channel float32) float64 {
+		v := float64(channel)
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	return float32(0.2126*linear(c.X) + 0.7152*linear(c.Y) + 0.0722*linear(c.Z))
+}
+
+// ContrastRatio returns the WCAG contrast ratio between two colors (1 to 21).
+// Text should have at least 4.5 against its background.
+func ContrastRatio(a, b imgui.Vec4) float32 {
+	la, lb := Luminance(a), Luminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// ToColor converts to Go's color.Color
+func ToColor(c imgui.Vec4) color.Color {
+	return color.NRGBA{
+		R: uint8(clamp01(c.X)*255 + 0.5),
+		G: uint8(clamp01(c.Y)*255 + 0.5),
+		B: uint8(clamp01(c.Z)*255 + 0.5),
+		A: uint8(clamp01(c.W)*255 + 0.5),
+	}
+}
+
+// FromColor converts any Go color.Color
+func FromColor(c color.Color) imgui.Vec4 {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return RGBA(float32(n.R), float32(n.G), float32(n.B), float32(n.A))
+}
+
+func maxComponent(c imgui.Vec4) float32 {
+	return float32(math.Max(float64(c.X), math.Max(float64(c.Y), float64(c.Z))))
+}
+
+func minComponent(c imgui.Vec4) float32 {
+	return float32(math.Min(float64(c.X), math.Min(float64(c.Y), float64(c.Z))))
+}
+
+// hue computes the hue in [0, 1] shared by the HSV and HSL conversions
+func hue(c imgui.Vec4, max, delta float32) float32 {
+	if delta == 0 {
+		return 0
+	}
+
+	var h float32
+	switch max {
+	case c.X:
+		h = (c.Y - c.Z) / delta
+		if h < 0 {
+			h += 6
+		}
+	case c.Y:
+		h = (c.Z-c.X)/delta + 2
+	default:
+		h = (c.X-c.Y)/delta + 4
+	}
+	return h / 6
+}
+
+func wrapHue(h float32) float32 {
+	h = float32(math.Mod(float64(h), 1))
+	if h < 0 {
+		h++
+	}
+	return h
+}
+
+func clamp01(x float32) float32 {
+	if x < 0 {
+		return 0
+	}
+	if x > 1 {
+		return 1
+	}
+	return x
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// ComboWidget is a dropdown choosing one of several items
+type ComboWidget struct {
+	id       string
+	label    string
+	selected *int32
+	items    []string
+	width    float32
+	flags    imgui.ComboFlags
+	onChange func()
+
+	disabledReason string
+}
+
+// Combo creates a dropdown over items; selected is the index of the chosen
+// item, or -1 for none
+func Combo(label string, selected *int32, items []string) *ComboWidget {
+	return &ComboWidget{
+		id:       fmt.Sprintf("%s##combo", label),
+		label:    label,
+		selected: selected,
+		items:    items,
+	}
+}
+
+// Size sets the dropdown width (builder pattern)
+func (c *ComboWidget) Size(width float32) *ComboWidget {
+	c.width = width
+	return c
+}
+
+// Flags sets the imgui combo flags, e.g. ComboFlagsHeightLargest (builder pattern)
+func (c *ComboWidget) Flags(flags imgui.ComboFlags) *ComboWidget {
+	c.flags = flags
+	return c
+}
+
+// OnChange sets the callback invoked when another item is chosen (builder pattern)
+func (c *ComboWidget) OnChange(onChange func()) *ComboWidget {
+	c.onChange = onChange
+	return c
+}
+
+// DisabledReason disables the dropdown and explains why in a tooltip (builder pattern)
+func (c *ComboWidget) DisabledReason(reason string) *ComboWidget {
+	c.disabledReason = reason
+	return c
+}
+
+func (c *ComboWidget) Build() {
+	if c.selected == nil {
+		ReportDiagnostic(fmt.Sprintf("combo %q has no bound value", c.label))
+		return
+	}
+
+	defaults := beginDefaults(KindCombo)
+	defer defaults.end()
+
+	width := c.width
+	if width <= 0 {
+		width = defaults.width
+	}
+	if width > 0 {
+		imgui.SetNextItemWidth(width)
+	}
+
+	if c.disabledReason != "" {
+		imgui.BeginDisabled()
+		defer endDisabledWithReason(c.disabledReason)
+	}
+
+	preview := ""
+	if *c.selected >= 0 && int(*c.selected) < len(c.items) {
+		preview = c.items[*c.selected]
+	}
+
+	if !imgui.BeginComboV(c.id, preview, c.flags) {
+		return
+	}
+	for i, item := range c.items {
+		isSelected := int32(i) == *c.selected
+		if imgui.SelectableBoolV(fmt.Sprintf("%s##%d", item, i), isSelected, 0, imgui.Vec2{}) && !isSelected {
+			*c.selected = int32(i)
+			if c.onChange != nil {
+				c.onChange()
+			}
+		}
+		// Open scrolled to the current item
+		if isSelected {
+			imgui.SetItemDefaultFocus()
+		}
+	}
+	imgui.EndCombo()
+}
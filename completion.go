@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// maxVisibleCompletions limits how many suggestions the popup shows at once
+const maxVisibleCompletions = 10
+
+// completionState holds the suggestions shown below an input
+type completionState struct {
+	items    []string
+	selected int
+	open     bool
+	pending  *string // suggestion picked with Enter, inserted by the next callback
+	accepted bool    // a suggestion was inserted this frame
+}
+
+func (s *completionState) Dispose() {
+	s.items = nil
+}
+
+// beginFrame runs before the input is built; Enter has to be caught here
+// because the input handles it before the callbacks run
+func (s *completionState) beginFrame() {
+	s.accepted = false
+	if s.open && len(s.items) > 0 && imgui.IsKeyPressedBoolV(imgui.KeyEnter, false) {
+		s.pending = &s.items[s.selected]
+	}
+}
+
+// navigate moves the selection with the arrow keys
+func (s *completionState) navigate(key imgui.Key) {
+	switch key {
+	case imgui.KeyUpArrow:
+		s.selected = (s.selected - 1 + len(s.items)) % len(s.items)
+	case imgui.KeyDownArrow:
+		s.selected = (s.selected + 1) % len(s.items)
+	}
+}
+
+// insert replaces the input's text with a suggestion
+func (s *completionState) insert(data imgui.InputTextCallbackData, text string) {
+	data.DeleteChars(0, data.BufTextLen())
+	data.InsertChars(0, text)
+	s.pending = nil
+	s.accepted = true
+	s.open = false
+}
+
+// update refreshes the suggestions after the input was built and draws them
+func (s *completionState) update(text string, edited bool, provider func(prefix string) []string) {
+	if !imgui.IsItemActive() || imgui.IsKeyPressedBoolV(imgui.KeyEscape, false) || s.accepted {
+		s.open = false
+		s.pending = nil
+		return
+	}
+
+	if edited {
+		s.items = nil
+		if text != "" {
+			s.items = provider(text)
+		}
+		s.selected = 0
+		s.open = len(s.items) > 0
+	}
+	if !s.open {
+		return
+	}
+
+	// Scroll the visible window so the selection stays in view
+	first := 0
+	if s.selected >= maxVisibleCompletions {
+		first = s.selected - maxVisibleCompletions + 1
+	}
+	last := min(first+maxVisibleCompletions, len(s.items))
+
+	// A tooltip never takes focus away from the input
+	imgui.SetNextWindowPosV(imgui.Vec2{X: imgui.ItemRectMin().X, Y: imgui.ItemRectMax().Y}, imgui.CondAlways, imgui.Vec2{})
+	if imgui.BeginTooltip() {
+		for index := first; index < last; index++ {
+			imgui.SelectableBoolV(s.items[index], index == s.selected, imgui.SelectableFlagsNone, imgui.Vec2{})
+		}
+		if len(s.items) > last {
+			imgui.TextDisabled(fmt.Sprintf("%d more", len(s.items)-last))
+		}
+		imgui.EndTooltip()
+	}
+}
+
+// inputCallback dispatches InputText callbacks: the arrows navigate open
+// suggestions before falling back to history, Tab accepts a suggestion
+func inputCallback(history *inputHistoryState, completion *completionState, current string) imgui.InputTextCallback {
+	return func(data imgui.InputTextCallbackData) int {
+		switch data.EventFlag() {
+		case imgui.InputTextFlagsCallbackHistory:
+			if completion != nil && completion.open {
+				completion.navigate(data.EventKey())
+			} else if history != nil {
+				history.recall(data, current)
+			}
+		case imgui.InputTextFlagsCallbackCompletion:
+			if completion != nil && completion.open {
+				completion.insert(data, completion.items[completion.selected])
+			}
+		case imgui.InputTextFlagsCallbackAlways:
+			if completion != nil && completion.pending != nil {
+				completion.insert(data, *completion.pending)
+			}
+		}
+		return 0
+	}
+}
+
+// Completions shows suggestions from provider below the field while typing;
+// the arrows pick one and Tab or Enter accepts it (builder pattern)
+func (i *InputTextWidget) Completions(provider func(prefix string) []string) *InputTextWidget {
+	i.completions = provider
+	return i
+}
+
+func (i *InputTextWidget) getCompletion() *completionState {
+	id := fmt.Sprintf("##completion_%d", imgui.IDStr(i.id))
+	if existingState, exists := GlobalContext.stateMap[id]; exists {
+		if state, ok := existingState.(*completionState); ok {
+			return state
+		}
+	}
+
+	newState := &completionState{}
+	GlobalContext.stateMap[id] = newState
+	return newState
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// ContextMenuWidget is a popup menu opened by clicking the previously built
+// item, by default with the right mouse button
+type ContextMenuWidget struct {
+	id      string
+	button  imgui.MouseButton
+	widgets []Widget
+}
+
+// ContextMenu creates a context menu for the previous item
+func ContextMenu() *ContextMenuWidget {
+	return &ContextMenuWidget{button: imgui.MouseButtonRight}
+}
+
+// ID names the popup. Needed when the previous item has no id of its own,
+// such as a Label (builder pattern)
+func (c *ContextMenuWidget) ID(id string) *ContextMenuWidget {
+	c.id = "##context_" + id
+	return c
+}
+
+// MouseButton sets the button that opens the menu (builder pattern)
+func (c *ContextMenuWidget) MouseButton(button imgui.MouseButton) *ContextMenuWidget {
+	c.button = button
+	return c
+}
+
+// Layout sets the menu's contents, typically MenuItem and MenuSeparator (builder pattern)
+func (c *ContextMenuWidget) Layout(widgets ...Widget) *ContextMenuWidget {
+	c.widgets = widgets
+	return c
+}
+
+func (c *ContextMenuWidget) Build() {
+	// Without a name the popup takes the item's id, which some items lack
+	if c.id == "" && imgui.ItemID() == 0 {
+		ReportDiagnostic("context menu on an item without an id; name it with ID")
+		return
+	}
+
+	if !imgui.BeginPopupContextItemV(c.id, imgui.PopupFlags(c.button)) {
+		return
+	}
+	for _, widget := range c.widgets {
+		buildWidget(widget)
+	}
+	imgui.EndPopup()
+}
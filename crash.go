@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// CrashReport describes a recovered panic
+type CrashReport struct {
+	Time        time.Time `json:"time"`
+	Panic       string    `json:"panic"`
+	Stack       string    `json:"stack"`
+	Log         []string  `json:"log"`
+	Diagnostics string    `json:"diagnostics"`
+	Description string    `json:"description"`
+}
+
+// String formats the report as plain text
+func (r CrashReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Crash at %s\n\n%s\n\n", r.Time.Format(time.RFC3339), r.Panic)
+	if r.Description != "" {
+		fmt.Fprintf(&b, "User description:\n%s\n\n", r.Description)
+	}
+	fmt.Fprintf(&b, "%s\n%s\nRecent log:\n", r.Diagnostics, r.Stack)
+	for _, line := range r.Log {
+		fmt.Fprintln(&b, line)
+	}
+	return b.String()
+}
+
+// CrashSubmitter sends a crash report somewhere; it runs off the UI thread
+type CrashSubmitter func(report CrashReport) error
+
+// CrashSubmitFile returns a submitter writing each report to a text file in dir
+func CrashSubmitFile(dir string) CrashSubmitter {
+	return func(report CrashReport) error {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("saving crash report: %w", err)
+		}
+		name := fmt.Sprintf("crash-%s.txt", report.Time.Format("20060102-150405"))
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(report.String()), 0o644); err != nil {
+			return fmt.Errorf("saving crash report: %w", err)
+		}
+		return nil
+	}
+}
+
+// CrashSubmitHTTP returns a submitter posting each report as JSON to url
+func CrashSubmitHTTP(url string) CrashSubmitter {
+	client := &http.Client{Timeout: 15 * time.Second}
+	return func(report CrashReport) error {
+		body, err := json.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("sending crash report: %w", err)
+		}
+		resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("sending crash report: %w", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			return fmt.Errorf("sending crash report: %s", resp.Status)
+		}
+		return nil
+	}
+}
+
+// recentLogLines is how much of the status log a crash report carries
+const recentLogLines = 200
+
+// Crash reporter state; pendingCrash and recentLog are shared with
+// goroutines calling RecoverCrash and logging
+var (
+	crashSubmit  CrashSubmitter
+	crashMu      sync.Mutex
+	pendingCrash *CrashReport
+	recentLog    []string
+	crashDialog  crashDialogState
+)
+
+// crashDialogState is the crash dialog's UI state
+type crashDialogState struct {
+	description string
+	submitting  bool
+	result      chan error
+	err         error
+	quit        bool
+}
+
+// SetCrashReporter recovers panics in the UI loop and in goroutines using
+// RecoverCrash, replacing the UI with a dialog showing the stack trace and
+// recent log. The user can describe what they were doing and send the
+// report through submit, then quit or carry on. Passing nil restores the
+// default of crashing.
+//
+// Dear ImGui's asserts on unbalanced Begin/End calls are turned off so a
+// panic partway through a window can be recovered within the frame.
+func (w *MasterWindow) SetCrashReporter(submit CrashSubmitter) {
+	crashSubmit = submit
+	imgui.CurrentIO().SetConfigErrorRecoveryEnableAssert(submit == nil)
+}
+
+// RecoverCrash records a panic for the crash dialog. Defer it at the top of
+// goroutines whose panics should be reported instead of killing the app:
+//
+//	go func() {
+//		defer RecoverCrash()
+//		...
+//	}()
+//
+// Without a crash reporter the panic continues.
+func RecoverCrash() {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+	if crashSubmit == nil {
+		panic(recovered)
+	}
+
+	crashMu.Lock()
+	defer crashMu.Unlock()
+	// The first crash is the interesting one
+	if pendingCrash != nil {
+		return
+	}
+	pendingCrash = &CrashReport{
+		Time:  time.Now(),
+		Panic: fmt.Sprint(recovered),
+		Stack: string(debug.Stack()),
+		Log:   append([]string(nil), recentLog...),
+	}
+}
+
+// rememberLogLine keeps the tail of the status log for crash reports
+func rememberLogLine(line string) {
+	crashMu.Lock()
+	defer crashMu.Unlock()
+	recentLog = append(recentLog, fmt.Sprintf("%s %s", time.Now().Format("15:04:05"), line))
+	if len(recentLog) > recentLogLines {
+		recentLog = recentLog[len(recentLog)-recentLogLines:]
+	}
+}
+
+// currentCrash returns the crash awaiting the user, if any
+func currentCrash() *CrashReport {
+	crashMu.Lock()
+	defer crashMu.Unlock()
+	return pendingCrash
+}
+
+// runGuarded runs the UI loop, recovering panics when a reporter is set
+func runGuarded(loopFunc func() error) error {
+	if crashSubmit == nil {
+		return loopFunc()
+	}
+	defer RecoverCrash()
+	return loopFunc()
+}
+
+// buildCrashDialog fills the window with the crash report and its actions
+func (w *MasterWindow) buildCrashDialog(report *CrashReport) {
+	if report.Diagnostics == "" {
+		report.Diagnostics = environmentDiagnostics()
+	}
+
+	// Finish a submission started on an earlier frame
+	dialog := &crashDialog
+	if dialog.submitting {
+		select {
+		case dialog.err = <-dialog.result:
+			dialog.submitting = false
+			if dialog.err == nil {
+				w.closeCrashDialog(dialog.quit)
+				return
+			}
+		default:
+		}
+	}
+
+	viewport := imgui.MainViewport()
+	imgui.SetNextWindowPos(viewport.WorkPos())
+	imgui.SetNextWindowSize(viewport.WorkSize())
+	flags := imgui.WindowFlagsNoDecoration | imgui.WindowFlagsNoMove | imgui.WindowFlagsNoSavedSettings
+	if imgui.BeginV("Crash Report##crash", nil, flags) {
+		imgui.TextColored(imgui.Vec4{X: 1, Y: 0.4, Z: 0.4, W: 1}, "The application stopped unexpectedly")
+		imgui.TextWrapped(report.Panic)
+
+		section := (imgui.ContentRegionAvail().Y - imgui.TextLineHeightWithSpacing()*8) / 3
+		imgui.SeparatorText("Stack trace")
+		crashText("##stack", report.Stack, section)
+		imgui.SeparatorText("Recent log")
+		crashText("##log", strings.Join(report.Log, "\n"), section)
+		imgui.SeparatorText("What were you doing?")
+		imgui.InputTextMultiline("##description", &dialog.description, imgui.Vec2{X: -1, Y: section}, 0, nil)
+
+		if dialog.err != nil {
+			imgui.TextColored(imgui.Vec4{X: 1, Y: 0.4, Z: 0.4, W: 1}, dialog.err.Error())
+		}
+
+		imgui.BeginDisabledV(dialog.submitting)
+		if imgui.Button("Send and Quit") {
+			w.submitCrash(report, true)
+		}
+		imgui.SameLine()
+		if imgui.Button("Send and Continue") {
+			w.submitCrash(report, false)
+		}
+		imgui.SameLine()
+		if imgui.Button("Copy Report") {
+			report.Description = dialog.description
+			imgui.SetClipboardText(report.String())
+		}
+		imgui.SameLine()
+		if imgui.Button("Quit") {
+			w.closeCrashDialog(true)
+		}
+		imgui.SameLine()
+		if imgui.Button("Continue") {
+			w.closeCrashDialog(false)
+		}
+		imgui.EndDisabled()
+		if dialog.submitting {
+			imgui.SameLine()
+			imgui.TextDisabled("Sending...")
+		}
+	}
+	imgui.End()
+}
+
+// crashText shows read-only text in a scrolling frame
+func crashText(id, text string, height float32) {
+	imgui.InputTextMultiline(id, &text, imgui.Vec2{X: -1, Y: height}, imgui.InputTextFlagsReadOnly, nil)
+}
+
+// submitCrash sends the report in the background, then quits or continues
+func (w *MasterWindow) submitCrash(report *CrashReport, quit bool) {
+	dialog := &crashDialog
+	report.Description = dialog.description
+	dialog.submitting = true
+	dialog.quit = quit
+	dialog.err = nil
+	result := make(chan error, 1)
+	dialog.result = result
+
+	submitted, submit := *report, crashSubmit
+	go func() {
+		result <- submit(submitted)
+	}()
+}
+
+// closeCrashDialog dismisses the crash, either quitting or resuming the UI
+func (w *MasterWindow) closeCrashDialog(quit bool) {
+	crashMu.Lock()
+	pendingCrash = nil
+	crashMu.Unlock()
+	crashDialog = crashDialogState{}
+
+	if quit {
+		w.backend.SetShouldClose(true)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"image"
+
+	"github.com/AllenDang/cimgui-go/backend"
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// MouseCursor identifies one of the standard mouse cursor shapes
+type MouseCursor imgui.MouseCursor
+
+const (
+	CursorArrow      = MouseCursor(imgui.MouseCursorArrow)
+	CursorTextInput  = MouseCursor(imgui.MouseCursorTextInput)
+	CursorResizeAll  = MouseCursor(imgui.MouseCursorResizeAll)
+	CursorResizeNS   = MouseCursor(imgui.MouseCursorResizeNS)
+	CursorResizeEW   = MouseCursor(imgui.MouseCursorResizeEW)
+	CursorResizeNESW = MouseCursor(imgui.MouseCursorResizeNESW)
+	CursorResizeNWSE = MouseCursor(imgui.MouseCursorResizeNWSE)
+	CursorHand       = MouseCursor(imgui.MouseCursorHand)
+	CursorNotAllowed = MouseCursor(imgui.MouseCursorNotAllowed)
+	CursorHidden     = MouseCursor(imgui.MouseCursorNone)
+)
+
+// SetCursor changes the mouse cursor for the current frame.
+// ImGui resets the cursor every frame, so call it from a widget's Build.
+func SetCursor(cursor MouseCursor) {
+	imgui.SetMouseCursor(imgui.MouseCursor(cursor))
+}
+
+// CustomCursor is a mouse cursor drawn from an image
+type CustomCursor struct {
+	image   image.Image
+	hotspot imgui.Vec2
+	texture *backend.Texture
+}
+
+// NewCustomCursor creates a cursor from an image; (hotX, hotY) is the pixel
+// that points at the mouse position
+func NewCustomCursor(img image.Image, hotX, hotY int) *CustomCursor {
+	return &CustomCursor{
+		image:   img,
+		hotspot: imgui.Vec2{X: float32(hotX), Y: float32(hotY)},
+	}
+}
+
+// SetCustomCursor replaces the mouse cursor with an image for the current frame.
+// The OS cursor is hidden and the image is drawn above all windows.
+func SetCustomCursor(cursor *CustomCursor) {
+	if cursor.texture == nil {
+		cursor.texture = backend.NewTextureFromRgba(imageToRGBA(cursor.image))
+	}
+
+	imgui.SetMouseCursor(imgui.MouseCursorNone)
+
+	bounds := cursor.image.Bounds()
+	min := imgui.MousePos().Sub(cursor.hotspot)
+	max := min.Add(imgui.Vec2{X: float32(bounds.Dx()), Y: float32(bounds.Dy())})
+	imgui.ForegroundDrawListViewportPtr().AddImage(cursor.texture.ID, min, max)
+}
+
+// Release frees the cursor's texture
+func (c *CustomCursor) Release() {
+	if c.texture != nil {
+		c.texture.Release()
+		c.texture = nil
+	}
+}
+
+// Cursor changes the mouse cursor while the widget is hovered (builder pattern)
+func (b *ButtonWidget) Cursor(cursor MouseCursor) *ButtonWidget {
+	b.cursor = &cursor
+	return b
+}
+
+// Cursor changes the mouse cursor while the label is hovered (builder pattern)
+func (l *LabelWidget) Cursor(cursor MouseCursor) *LabelWidget {
+	l.cursor = &cursor
+	return l
+}
+
+// Cursor changes the mouse cursor while the previous item is hovered (builder pattern)
+func (e *EventWidget) Cursor(cursor MouseCursor) *EventWidget {
+	e.cursor = &cursor
+	return e
+}
+
+// applyHoverCursor sets cursor if the previous item is hovered
+func applyHoverCursor(cursor *MouseCursor) {
+	if cursor != nil && imgui.IsItemHovered() {
+		SetCursor(*cursor)
+	}
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// SortDirection is the order a TableModel sorts a column in
+type SortDirection int
+
+const (
+	SortAscending SortDirection = iota
+	SortDescending
+)
+
+// TableModel supplies the rows of a DataGrid. The grid only asks for the
+// cells it shows, so models can pull from databases or remote sources.
+type TableModel interface {
+	ColumnCount() int
+	ColumnName(col int) string
+	RowCount() int
+	Cell(row, col int) string
+	Sort(col int, direction SortDirection)
+	Filter(query string)
+}
+
+// RangeFetcher is implemented by models that load rows lazily; the grid
+// calls Fetch with the visible rows [first, last) before reading their cells
+type RangeFetcher interface {
+	Fetch(first, last int)
+}
+
+// NullReporter is implemented by models that distinguish missing values;
+// the grid shows them as a dimmed NULL
+type NullReporter interface {
+	IsNull(row, col int) bool
+}
+
+// ColumnTyper is implemented by models that know their column types; the
+// grid shows the type next to the column name
+type ColumnTyper interface {
+	ColumnType(col int) string
+}
+
+// SliceModel is an in-memory TableModel over rows of strings
+type SliceModel struct {
+	columns []string
+	rows    [][]string
+	visible []int // indices into rows after filtering and sorting
+}
+
+// NewSliceModel creates a model over rows; each row has one cell per column
+func NewSliceModel(columns []string, rows [][]string) *SliceModel {
+	m := &SliceModel{columns: columns, rows: rows}
+	m.Filter("")
+	return m
+}
+
+func (m *SliceModel) ColumnCount() int          { return len(m.columns) }
+func (m *SliceModel) ColumnName(col int) string { return m.columns[col] }
+func (m *SliceModel) RowCount() int             { return len(m.visible) }
+
+func (m *SliceModel) Cell(row, col int) string {
+	cells := m.rows[m.visible[row]]
+	if col >= len(cells) {
+		return ""
+	}
+	return cells[col]
+}
+
+// Sort orders the visible rows by a column, comparing as text
+func (m *SliceModel) Sort(col int, direction SortDirection) {
+	sort.SliceStable(m.visible, func(i, j int) bool {
+		a, b := m.Cell(i, col), m.Cell(j, col)
+		if direction == SortDescending {
+			return a > b
+		}
+		return a < b
+	})
+}
+
+// Filter keeps the rows with a cell containing query, ignoring case
+func (m *SliceModel) Filter(query string) {
+	query = strings.ToLower(query)
+	m.visible = m.visible[:0]
+	for i, cells := range m.rows {
+		if query == "" {
+			m.visible = append(m.visible, i)
+			continue
+		}
+		for _, cell := range cells {
+			if strings.Contains(strings.ToLower(cell), query) {
+				m.visible = append(m.visible, i)
+				break
+			}
+		}
+	}
+}
+
+// dataGridState holds the filter text and the selection
+type dataGridState struct {
+	filter        string
+	appliedFilter string
+	selected      int
+}
+
+func (s *dataGridState) Dispose() {
+	// Nothing to clean up
+}
+
+func (s *dataGridState) Snapshot() any {
+	return map[string]any{"filter": s.filter, "selected": s.selected}
+}
+
+// DataGridWidget displays a TableModel, building only the visible rows
+type DataGridWidget struct {
+	id         string
+	model      TableModel
+	height     float32
+	filterable bool
+	onSelect   func(row int)
+}
+
+// DataGrid creates a grid showing model
+func DataGrid(id string, model TableModel) *DataGridWidget {
+	return &DataGridWidget{
+		id:    fmt.Sprintf("##datagrid_%s", id),
+		model: model,
+	}
+}
+
+// Height sets the grid height; zero fills the remaining space (builder pattern)
+func (d *DataGridWidget) Height(height float32) *DataGridWidget {
+	d.height = height
+	return d
+}
+
+// Filterable shows a filter field above the grid that calls the model's Filter (builder pattern)
+func (d *DataGridWidget) Filterable(filterable bool) *DataGridWidget {
+	d.filterable = filterable
+	return d
+}
+
+// OnSelect sets the callback invoked with the clicked row (builder pattern)
+func (d *DataGridWidget) OnSelect(onSelect func(row int)) *DataGridWidget {
+	d.onSelect = onSelect
+	return d
+}
+
+// Selected returns the selected row, or -1
+func (d *DataGridWidget) Selected() int {
+	return d.getState().selected
+}
+
+func (d *DataGridWidget) getState() *dataGridState {
+	if existingState, exists := GlobalContext.stateMap[d.id]; exists {
+		if state, ok := existingState.(*dataGridState); ok {
+			return state
+		}
+	}
+
+	newState := &dataGridState{selected: -1}
+	GlobalContext.stateMap[d.id] = newState
+	return newState
+}
+
+func (d *DataGridWidget) Build() {
+	if d.model == nil {
+		ReportDiagnostic(fmt.Sprintf("data grid %q has no model", d.id))
+		return
+	}
+
+	state := d.getState()
+	imgui.PushIDStr(d.id)
+	defer imgui.PopID()
+
+	if d.filterable {
+		imgui.SetNextItemWidth(-1)
+		imgui.InputTextWithHint("##filter", "Filter", &state.filter, 0, nil)
+		if state.filter != state.appliedFilter {
+			state.appliedFilter = state.filter
+			state.selected = -1
+			d.model.Filter(state.filter)
+		}
+	}
+
+	columns := d.model.ColumnCount()
+	if columns == 0 {
+		return
+	}
+
+	flags := imgui.TableFlagsScrollY | imgui.TableFlagsRowBg | imgui.TableFlagsBorders |
+		imgui.TableFlagsResizable | imgui.TableFlagsSortable
+	if !imgui.BeginTableV("##grid", int32(columns), flags, imgui.Vec2{Y: d.height}, 0) {
+		return
+	}
+
+	imgui.TableSetupScrollFreeze(0, 1)
+	typer, _ := d.model.(ColumnTyper)
+	for col := 0; col < columns; col++ {
+		name := d.model.ColumnName(col)
+		if typer != nil && typer.ColumnType(col) != "" {
+			name = fmt.Sprintf("%s (%s)", name, typer.ColumnType(col))
+		}
+		imgui.TableSetupColumn(name)
+	}
+	imgui.TableHeadersRow()
+	d.applySort()
+
+	rows := d.model.RowCount()
+	clipper := imgui.NewListClipper()
+	clipper.Begin(int32(rows))
+	for clipper.Step() {
+		first, last := int(clipper.DisplayStart()), int(clipper.DisplayEnd())
+		if fetcher, ok := d.model.(RangeFetcher); ok {
+			fetcher.Fetch(first, last)
+		}
+
+		for row := first; row < last; row++ {
+			imgui.TableNextRow()
+			for col := 0; col < columns; col++ {
+				imgui.TableNextColumn()
+				d.buildCell(state, row, col)
+			}
+		}
+	}
+	clipper.End()
+	clipper.Destroy()
+
+	imgui.EndTable()
+}
+
+// buildCell draws one cell; the first column carries the row's selectable
+func (d *DataGridWidget) buildCell(state *dataGridState, row, col int) {
+	text := d.model.Cell(row, col)
+	null := false
+	if reporter, ok := d.model.(NullReporter); ok && reporter.IsNull(row, col) {
+		text, null = "NULL", true
+	}
+	if col != 0 {
+		if null {
+			imgui.TextDisabled(text)
+		} else {
+			imgui.TextUnformatted(text)
+		}
+		return
+	}
+
+	label := fmt.Sprintf("%s##row_%d", text, row)
+	if imgui.SelectableBoolV(label, state.selected == row, imgui.SelectableFlagsSpanAllColumns, imgui.Vec2{}) {
+		state.selected = row
+		if d.onSelect != nil {
+			d.onSelect(row)
+		}
+	}
+}
+
+// applySort forwards header clicks to the model
+func (d *DataGridWidget) applySort() {
+	specs := imgui.TableGetSortSpecs()
+	if specs == nil || !specs.SpecsDirty() {
+		return
+	}
+
+	if specs.SpecsCount() > 0 {
+		column := specs.Specs()
+		direction := SortAscending
+		if column.SortDirection() == imgui.SortDirectionDescending {
+			direction = SortDescending
+		}
+		d.model.Sort(int(column.ColumnIndex()), direction)
+		d.getState().selected = -1
+	}
+	specs.SetSpecsDirty(false)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// WidgetKind identifies a widget type for default styling
+type WidgetKind string
+
+const (
+	KindLabel       WidgetKind = "Label"
+	KindButton      WidgetKind = "Button"
+	KindInputText   WidgetKind = "InputText"
+	KindCheckbox    WidgetKind = "Checkbox"
+	KindSlider      WidgetKind = "Slider"
+	KindColorEdit   WidgetKind = "ColorEdit"
+	KindProgressBar WidgetKind = "ProgressBar"
+	KindCombo       WidgetKind = "Combo"
+)
+
+// WidgetDefaults is the style applied to every widget of one kind
+type WidgetDefaults struct {
+	colors map[int]imgui.Vec4
+	vars   map[int]float32
+	width  float32
+}
+
+// Defaults creates an empty set of widget defaults
+func Defaults() *WidgetDefaults {
+	return &WidgetDefaults{
+		colors: make(map[int]imgui.Vec4),
+		vars:   make(map[int]float32),
+	}
+}
+
+// SetColor sets a default style color (builder pattern)
+func (d *WidgetDefaults) SetColor(colorID int, color imgui.Vec4) *WidgetDefaults {
+	d.colors[colorID] = color
+	return d
+}
+
+// SetVar sets a default style variable (builder pattern)
+func (d *WidgetDefaults) SetVar(varID int, value float32) *WidgetDefaults {
+	d.vars[varID] = value
+	return d
+}
+
+// Width sets the default item width, used when the widget has no size of its own (builder pattern)
+func (d *WidgetDefaults) Width(width float32) *WidgetDefaults {
+	d.width = width
+	return d
+}
+
+// SetDefaultStyle registers the defaults applied to all widgets of a kind
+func (c *Context) SetDefaultStyle(kind WidgetKind, defaults *WidgetDefaults) {
+	c.defaultStyles[kind] = defaults
+}
+
+// ClearDefaultStyle removes the defaults registered for a kind
+func (c *Context) ClearDefaultStyle(kind WidgetKind) {
+	delete(c.defaultStyles, kind)
+}
+
+// isColorOverridden reports whether an enclosing StyleSetter sets the color
+func (c *Context) isColorOverridden(colorID int) bool {
+	for _, setter := range c.styleOverrides {
+		if _, exists := setter.colors[colorID]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// isVarOverridden reports whether an enclosing StyleSetter sets the variable
+func (c *Context) isVarOverridden(varID int) bool {
+	for _, setter := range c.styleOverrides {
+		if _, exists := setter.vars[varID]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// appliedDefaults remembers what beginDefaults pushed so it can be popped
+type appliedDefaults struct {
+	colorCount int32
+	varCount   int32
+	width      float32
+}
+
+// beginDefaults pushes the registered defaults for kind, skipping anything
+// a surrounding StyleSetter overrides locally
+func beginDefaults(kind WidgetKind) appliedDefaults {
+	var applied appliedDefaults
+
+	defaults, exists := GlobalContext.defaultStyles[kind]
+	if !exists {
+		return applied
+	}
+
+	for colorID, color := range defaults.colors {
+		if !GlobalContext.isColorOverridden(colorID) {
+			PushStyleColor(imgui.Col(colorID), color)
+			applied.colorCount++
+		}
+	}
+
+	for varID, value := range defaults.vars {
+		if !GlobalContext.isVarOverridden(varID) {
+			PushStyleVar(imgui.StyleVar(varID), value)
+			applied.varCount++
+		}
+	}
+
+	applied.width = defaults.width
+	return applied
+}
+
+// end pops the styles pushed by beginDefaults
+func (a appliedDefaults) end() {
+	PopStyleVar(a.varCount)
+	PopStyleColor(a.colorCount)
+}
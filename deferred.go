@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// deferredState remembers the size of the last real build
+type deferredState struct {
+	size    imgui.Vec2
+	built   bool
+	skipped int // frames skipped since the last build
+}
+
+func (s *deferredState) Dispose() {
+	// Nothing to clean up
+}
+
+// DeferredWidget skips building an expensive subtree while it is scrolled
+// out of view, reserving the space it took when it was last built so
+// scrollbars and the layout below stay put
+type DeferredWidget struct {
+	id     string
+	widget Widget
+	every  int
+}
+
+// Deferred wraps widget so it is only built while on screen
+func Deferred(id string, widget Widget) *DeferredWidget {
+	return &DeferredWidget{
+		id:     fmt.Sprintf("##deferred_%s", id),
+		widget: widget,
+	}
+}
+
+// Every still builds the widget every n frames while it is off screen, for
+// subtrees whose Build has side effects that must keep running (builder pattern)
+func (d *DeferredWidget) Every(n int) *DeferredWidget {
+	d.every = n
+	return d
+}
+
+func (d *DeferredWidget) getState() *deferredState {
+	if existingState, exists := GlobalContext.stateMap[d.id]; exists {
+		if state, ok := existingState.(*deferredState); ok {
+			return state
+		}
+	}
+
+	newState := &deferredState{}
+	GlobalContext.stateMap[d.id] = newState
+	return newState
+}
+
+func (d *DeferredWidget) Build() {
+	state := d.getState()
+
+	// The size is unknown until the first build, so that one always happens
+	if state.built {
+		probe := imgui.Vec2{X: max(state.size.X, 1), Y: max(state.size.Y, 1)}
+		due := d.every > 0 && state.skipped+1 >= d.every
+		if !imgui.IsRectVisible(probe) && !due {
+			state.skipped++
+			imgui.Dummy(state.size)
+			return
+		}
+	}
+
+	imgui.BeginGroup()
+	buildWidget(d.widget)
+	imgui.EndGroup()
+
+	state.size = imgui.ItemRectSize()
+	state.built = true
+	state.skipped = 0
+}
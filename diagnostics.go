@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Diagnostic is a non-fatal problem found while building a frame
+type Diagnostic struct {
+	Frame   int32
+	Widget  string // path of the widgets being built, see widgetPath; empty outside widgets
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	if d.Widget == "" {
+		return d.Message
+	}
+	return fmt.Sprintf("%s: %s", d.Widget, d.Message)
+}
+
+// Diagnostics collected this frame and the last complete frame
+var (
+	frameDiagnostics    []Diagnostic
+	lastDiagnostics     []Diagnostic
+	reportedDiagnostics = make(map[string]int32) // frame each problem was last logged
+)
+
+// A problem is logged again once it has gone unlogged for
+// diagnosticRepeatFrames; older entries are forgotten so the map stays small
+const diagnosticRepeatFrames = 600
+
+// buildingWidgets are the widgets whose Build is running, outermost first,
+// kept by buildWidget
+var buildingWidgets []Widget
+
+// widgetName returns a widget's type without the package, followed by its
+// ID, label or title when it has one, e.g. `ComboWidget "Theme"`
+func widgetName(widget Widget) string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", widget), "*main.")
+
+	value := reflect.ValueOf(widget)
+	if value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return name
+	}
+	for _, field := range []string{"id", "label", "title"} {
+		if f := value.FieldByName(field); f.Kind() == reflect.String && f.String() != "" {
+			return fmt.Sprintf("%s %q", name, f.String())
+		}
+	}
+	return name
+}
+
+// widgetPath names the widgets being built from the outermost down, e.g.
+// `RowWidget/ComboWidget "Theme"`
+func widgetPath() string {
+	names := make([]string, len(buildingWidgets))
+	for i, widget := range buildingWidgets {
+		names[i] = widgetName(widget)
+	}
+	return strings.Join(names, "/")
+}
+
+// ReportDiagnostic records a problem with the widget being built, such as a
+// missing texture or a nil binding. Widgets report and skip drawing instead
+// of panicking. A recurring problem is logged at most once every
+// diagnosticRepeatFrames frames.
+func ReportDiagnostic(message string) {
+	diagnostic := Diagnostic{Frame: imgui.FrameCount(), Widget: widgetPath(), Message: message}
+	frameDiagnostics = append(frameDiagnostics, diagnostic)
+
+	text := diagnostic.String()
+	if last, logged := reportedDiagnostics[text]; !logged || diagnostic.Frame-last >= diagnosticRepeatFrames {
+		reportedDiagnostics[text] = diagnostic.Frame
+		LogWarning(text)
+	}
+}
+
+// Diagnostics returns the problems reported during the last complete frame
+func Diagnostics() []Diagnostic {
+	return append([]Diagnostic(nil), lastDiagnostics...)
+}
+
+// endDiagnosticsFrame publishes this frame's diagnostics; runs after the
+// user's loop
+func endDiagnosticsFrame() {
+	lastDiagnostics, frameDiagnostics = frameDiagnostics, lastDiagnostics[:0]
+
+	frame := imgui.FrameCount()
+	if frame%diagnosticRepeatFrames == 0 {
+		for text, last := range reportedDiagnostics {
+			if frame-last >= diagnosticRepeatFrames {
+				delete(reportedDiagnostics, text)
+			}
+		}
+	}
+}
+
+// DiagnosticsViewWidget lists the problems reported during the last frame
+// with the widget each one came from
+type DiagnosticsViewWidget struct{}
+
+// DiagnosticsView creates the diagnostics panel
+func DiagnosticsView() *DiagnosticsViewWidget {
+	return &DiagnosticsViewWidget{}
+}
+
+func (d *DiagnosticsViewWidget) Build() {
+	if len(lastDiagnostics) == 0 {
+		imgui.TextDisabled("no problems")
+		return
+	}
+
+	flags := imgui.TableFlagsRowBg | imgui.TableFlagsBorders | imgui.TableFlagsResizable
+	if !imgui.BeginTableV("##diagnostics", 2, flags, imgui.Vec2{}, 0) {
+		return
+	}
+	imgui.TableSetupColumn("Widget")
+	imgui.TableSetupColumn("Problem")
+	imgui.TableHeadersRow()
+
+	for _, diagnostic := range lastDiagnostics {
+		imgui.TableNextRow()
+		imgui.TableNextColumn()
+		imgui.TextUnformatted(diagnostic.Widget)
+		if imgui.IsItemHovered() {
+			imgui.SetTooltip(diagnostic.Widget)
+		}
+		imgui.TableNextColumn()
+		imgui.TextUnformatted(diagnostic.Message)
+	}
+	imgui.EndTable()
+}
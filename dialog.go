@@ -0,0 +1,248 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// queuedDialog is a modal waiting its turn in the dialog queue
+type queuedDialog struct {
+	id    int
+	title string
+	// build draws the dialog's contents and reports whether it is finished
+	build func() bool
+}
+
+// Dialog queue; dialogs can be queued from any goroutine and are shown
+// one at a time in the order they were queued
+var (
+	dialogMu     sync.Mutex
+	dialogQueue  []*queuedDialog
+	nextDialogID int
+)
+
+// enqueueDialog adds a modal to the back of the dialog queue
+func enqueueDialog(title string, build func() bool) {
+	dialogMu.Lock()
+	defer dialogMu.Unlock()
+	nextDialogID++
+	dialogQueue = append(dialogQueue, &queuedDialog{id: nextDialogID, title: title, build: build})
+}
+
+// drawDialogs shows the dialog at the front of the queue, removing it once
+// it is finished
+func drawDialogs() {
+	dialogMu.Lock()
+	if len(dialogQueue) == 0 {
+		dialogMu.Unlock()
+		return
+	}
+	dialog := dialogQueue[0]
+	dialogMu.Unlock()
+
+	// The id keeps dialogs with the same title apart
+	popupID := fmt.Sprintf("%s###dialog%d", dialog.title, dialog.id)
+	if !imgui.IsPopupOpenStr(popupID) {
+		imgui.OpenPopupStr(popupID)
+	}
+
+	center := imgui.MainViewport().Center()
+	imgui.SetNextWindowPosV(center, imgui.CondAppearing, imgui.Vec2{X: 0.5, Y: 0.5})
+	if !imgui.BeginPopupModalV(popupID, nil, imgui.WindowFlagsAlwaysAutoResize|imgui.WindowFlagsNoSavedSettings) {
+		return
+	}
+	defer imgui.EndPopup()
+
+	if !dialog.build() {
+		return
+	}
+	imgui.CloseCurrentPopup()
+	dialogMu.Lock()
+	dialogQueue = slices.DeleteFunc(dialogQueue, func(d *queuedDialog) bool { return d == dialog })
+	dialogMu.Unlock()
+}
+
+// Confirm asks the user a yes/no question in a modal dialog. The channel
+// receives true for OK and false for Cancel or Escape. Safe to call from
+// any goroutine:
+//
+//	go func() {
+//		if <-Confirm("Delete", "Delete 3 files?") {
+//			...
+//		}
+//	}()
+func Confirm(title, message string) <-chan bool {
+	result := make(chan bool, 1)
+	Msgbox(title, message).
+		Buttons(MsgboxButtonsOkCancel).
+		ResultCallback(func(r DialogResult) { result <- r == DialogResultOK })
+	return result
+}
+
+// PromptResult is the answer to a Prompt
+type PromptResult struct {
+	Text string
+	OK   bool // false if the user cancelled
+}
+
+// Prompt asks the user for a line of text in a modal dialog, starting from
+// defaultText. Enter or OK accepts; Cancel or Escape gives OK false. Safe
+// to call from any goroutine.
+func Prompt(title, message, defaultText string) <-chan PromptResult {
+	result := make(chan PromptResult, 1)
+	text := defaultText
+	enqueueDialog(title, func() bool {
+		imgui.Text(message)
+		if imgui.IsWindowAppearing() {
+			imgui.SetKeyboardFocusHere()
+		}
+		imgui.SetNextItemWidth(max(300, imgui.CalcTextSize(message).X))
+		submitted := imgui.InputTextWithHint("##prompt", "", &text, imgui.InputTextFlagsEnterReturnsTrue, nil)
+		imgui.Separator()
+		if imgui.Button("OK") || submitted {
+			result <- PromptResult{Text: text, OK: true}
+			return true
+		}
+		imgui.SameLine()
+		if imgui.Button("Cancel") || imgui.IsKeyPressedBool(imgui.KeyEscape) {
+			result <- PromptResult{Text: text}
+			return true
+		}
+		return false
+	})
+	return result
+}
+
+// MsgboxButtons selects the buttons of a message box
+type MsgboxButtons int
+
+const (
+	MsgboxButtonsOk MsgboxButtons = iota
+	MsgboxButtonsOkCancel
+	MsgboxButtonsYesNo
+	MsgboxButtonsYesNoCancel
+)
+
+// DialogResult is the button the user chose in a message box
+type DialogResult int
+
+const (
+	DialogResultOK DialogResult = iota
+	DialogResultCancel
+	DialogResultYes
+	DialogResultNo
+)
+
+func (r DialogResult) String() string {
+	switch r {
+	case DialogResultOK:
+		return "OK"
+	case DialogResultCancel:
+		return "Cancel"
+	case DialogResultYes:
+		return "Yes"
+	case DialogResultNo:
+		return "No"
+	default:
+		return "Unknown"
+	}
+}
+
+// msgboxButton is one button of a message box and the result it gives
+type msgboxButton struct {
+	label  string
+	result DialogResult
+}
+
+// buttons returns the buttons in display order
+func (b MsgboxButtons) buttons() []msgboxButton {
+	switch b {
+	case MsgboxButtonsOkCancel:
+		return []msgboxButton{{"OK", DialogResultOK}, {"Cancel", DialogResultCancel}}
+	case MsgboxButtonsYesNo:
+		return []msgboxButton{{"Yes", DialogResultYes}, {"No", DialogResultNo}}
+	case MsgboxButtonsYesNoCancel:
+		return []msgboxButton{{"Yes", DialogResultYes}, {"No", DialogResultNo}, {"Cancel", DialogResultCancel}}
+	default:
+		return []msgboxButton{{"OK", DialogResultOK}}
+	}
+}
+
+// escapeResult is the result given when the user presses Escape: Cancel
+// if there is such a button, otherwise the last button
+func (b MsgboxButtons) escapeResult() DialogResult {
+	buttons := b.buttons()
+	return buttons[len(buttons)-1].result
+}
+
+// MsgboxDialog is a queued message box; configure it right after creating it
+type MsgboxDialog struct {
+	title    string
+	content  string
+	buttons  MsgboxButtons
+	onResult func(result DialogResult)
+}
+
+// Msgbox queues a message box with an OK button. Message boxes and other
+// dialogs are shown one at a time in the order they were queued. Safe to
+// call from any goroutine.
+func Msgbox(title, content string) *MsgboxDialog {
+	m := &MsgboxDialog{title: title, content: content}
+	enqueueDialog(title, m.build)
+	return m
+}
+
+// Buttons sets the buttons offered (builder pattern)
+func (m *MsgboxDialog) Buttons(buttons MsgboxButtons) *MsgboxDialog {
+	dialogMu.Lock()
+	defer dialogMu.Unlock()
+	m.buttons = buttons
+	return m
+}
+
+// ResultCallback sets the callback invoked on the UI thread with the
+// button chosen (builder pattern)
+func (m *MsgboxDialog) ResultCallback(onResult func(result DialogResult)) *MsgboxDialog {
+	dialogMu.Lock()
+	defer dialogMu.Unlock()
+	m.onResult = onResult
+	return m
+}
+
+// build draws the message box, reporting whether a button was chosen
+func (m *MsgboxDialog) build() bool {
+	// The builders may still be running on another goroutine
+	dialogMu.Lock()
+	buttons, onResult := m.buttons, m.onResult
+	dialogMu.Unlock()
+
+	imgui.Text(m.content)
+	imgui.Separator()
+
+	chosen := -1
+	for i, button := range buttons.buttons() {
+		if i > 0 {
+			imgui.SameLine()
+		}
+		if imgui.Button(button.label) {
+			chosen = int(button.result)
+		}
+		if i == 0 {
+			imgui.SetItemDefaultFocus()
+		}
+	}
+	if chosen < 0 && imgui.IsKeyPressedBool(imgui.KeyEscape) {
+		chosen = int(buttons.escapeResult())
+	}
+	if chosen < 0 {
+		return false
+	}
+
+	if onResult != nil {
+		onResult(DialogResult(chosen))
+	}
+	return true
+}
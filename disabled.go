@@ -0,0 +1,83 @@
+package main
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// endDisabledWithReason closes a disabled block around a single item and
+// explains why it is disabled while the item is hovered
+func endDisabledWithReason(reason string) {
+	imgui.EndDisabled()
+	if imgui.IsItemHoveredV(imgui.HoveredFlagsAllowWhenDisabled) {
+		imgui.SetTooltip(reason)
+	}
+}
+
+// DisabledWidget greys out its children and blocks their input, optionally
+// showing why in a tooltip
+type DisabledWidget struct {
+	widgets  []Widget
+	disabled bool
+	reason   string
+}
+
+// Disabled disables the widgets; use When to make it conditional
+func Disabled(widgets ...Widget) *DisabledWidget {
+	return &DisabledWidget{widgets: widgets, disabled: true}
+}
+
+// When disables the widgets only if disabled is true (builder pattern)
+func (d *DisabledWidget) When(disabled bool) *DisabledWidget {
+	d.disabled = disabled
+	return d
+}
+
+// Reason sets the tooltip shown while the disabled widgets are hovered (builder pattern)
+func (d *DisabledWidget) Reason(reason string) *DisabledWidget {
+	d.reason = reason
+	return d
+}
+
+func (d *DisabledWidget) Build() {
+	if !d.disabled {
+		for _, widget := range d.widgets {
+			buildWidget(widget)
+		}
+		return
+	}
+
+	// The group makes the tooltip cover all the widgets
+	imgui.BeginDisabled()
+	imgui.BeginGroup()
+	for _, widget := range d.widgets {
+		buildWidget(widget)
+	}
+	imgui.EndGroup()
+
+	if d.reason != "" {
+		endDisabledWithReason(d.reason)
+	} else {
+		imgui.EndDisabled()
+	}
+}
+
+// DisabledReason disables the button and shows reason when it is hovered;
+// an empty reason enables it again (builder pattern)
+func (b *ButtonWidget) DisabledReason(reason string) *ButtonWidget {
+	b.disabledReason = reason
+	return b
+}
+
+// DisabledReason disables the checkbox and shows reason when it is hovered;
+// an empty reason enables it again (builder pattern)
+func (c *CheckboxWidget) DisabledReason(reason string) *CheckboxWidget {
+	c.disabledReason = reason
+	return c
+}
+
+// DisabledReason disables the input and shows reason when it is hovered;
+// an empty reason enables it again (builder pattern)
+func (i *InputTextWidget) DisabledReason(reason string) *InputTextWidget {
+	i.disabledReason = reason
+	return i
+}
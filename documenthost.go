@@ -0,0 +1,342 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Document is a tab in a DocumentHost
+type Document struct {
+	host   *DocumentHost
+	id     int
+	title  string
+	dirty  bool
+	layout func() Layout
+	onSave func() error
+
+	// Values whose unsaved changes make the document dirty
+	tracked []Trackable
+
+	selectNext bool
+}
+
+// Title returns the document's tab title
+func (d *Document) Title() string {
+	return d.title
+}
+
+// SetTitle changes the tab title, e.g. after Save As
+func (d *Document) SetTitle(title string) {
+	d.title = title
+}
+
+// Dirty reports whether the document has unsaved changes, either marked
+// with SetDirty or in one of its tracked values
+func (d *Document) Dirty() bool {
+	if d.dirty {
+		return true
+	}
+	for _, value := range d.tracked {
+		if value.Dirty() {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDirty marks the document as having unsaved changes, shown as a dot on
+// its tab and confirmed before closing
+func (d *Document) SetDirty(dirty bool) {
+	d.dirty = dirty
+}
+
+// Track adds values whose unsaved changes mark the document dirty; they
+// are marked saved when the document is saved (builder pattern)
+func (d *Document) Track(values ...Trackable) *Document {
+	d.tracked = append(d.tracked, values...)
+	return d
+}
+
+// OnSave sets the function saving the document, offered when a dirty
+// document is closed (builder pattern)
+func (d *Document) OnSave(onSave func() error) *Document {
+	d.onSave = onSave
+	return d
+}
+
+// Save calls the save function and, if it succeeds, clears the dirty flag
+// and marks the tracked values saved
+func (d *Document) Save() error {
+	if d.onSave == nil {
+		return fmt.Errorf("document %q cannot be saved", d.title)
+	}
+	if err := d.onSave(); err != nil {
+		return err
+	}
+	d.dirty = false
+	for _, value := range d.tracked {
+		value.MarkSaved()
+	}
+	return nil
+}
+
+// Activate selects the document's tab on the next frame
+func (d *Document) Activate() {
+	d.selectNext = true
+}
+
+// Close closes the document, asking first if it has unsaved changes
+func (d *Document) Close() {
+	d.host.requestClose(d)
+}
+
+// DocumentHost shows open documents as tabs with unsaved markers, asks
+// before closing unsaved work and switches between documents in
+// most-recently-used order with Ctrl+Tab
+type DocumentHost struct {
+	id        string
+	documents []*Document
+	mru       []*Document
+	nextID    int
+	onClose   func(doc *Document)
+
+	closing   *Document
+	saveErr   error
+	switching bool
+	switchTo  int
+}
+
+// NewDocumentHost creates an empty document host
+func NewDocumentHost(id string) *DocumentHost {
+	return &DocumentHost{id: fmt.Sprintf("##documents_%s", id)}
+}
+
+// OnClose sets the callback invoked after a document is closed (builder pattern)
+func (h *DocumentHost) OnClose(onClose func(doc *Document)) *DocumentHost {
+	h.onClose = onClose
+	return h
+}
+
+// Open adds a document as a new tab and selects it. layout builds the
+// document's contents and is only called while its tab is selected.
+func (h *DocumentHost) Open(title string, layout func() Layout) *Document {
+	h.nextID++
+	doc := &Document{host: h, id: h.nextID, title: title, layout: layout, selectNext: true}
+	h.documents = append(h.documents, doc)
+	h.mru = append([]*Document{doc}, h.mru...)
+	return doc
+}
+
+// Documents returns the open documents in tab order
+func (h *DocumentHost) Documents() []*Document {
+	return h.documents
+}
+
+// Active returns the selected document, or nil
+func (h *DocumentHost) Active() *Document {
+	if len(h.mru) == 0 {
+		return nil
+	}
+	return h.mru[0]
+}
+
+// requestClose closes doc, or asks first if it is dirty
+func (h *DocumentHost) requestClose(doc *Document) {
+	if doc.Dirty() {
+		h.closing = doc
+		h.saveErr = nil
+		return
+	}
+	h.remove(doc)
+}
+
+// remove drops doc from the host
+func (h *DocumentHost) remove(doc *Document) {
+	h.documents = slices.DeleteFunc(h.documents, func(d *Document) bool { return d == doc })
+	h.mru = slices.DeleteFunc(h.mru, func(d *Document) bool { return d == doc })
+	if h.closing == doc {
+		h.closing = nil
+	}
+	if h.onClose != nil {
+		h.onClose(doc)
+	}
+}
+
+// touch moves doc to the front of the most-recently-used order
+func (h *DocumentHost) touch(doc *Document) {
+	if len(h.mru) > 0 && h.mru[0] == doc {
+		return
+	}
+	h.mru = slices.DeleteFunc(h.mru, func(d *Document) bool { return d == doc })
+	h.mru = append([]*Document{doc}, h.mru...)
+}
+
+func (h *DocumentHost) Build() {
+	imgui.PushIDStr(h.id)
+	defer imgui.PopID()
+
+	claimCtrlTab()
+	h.handleSwitcher()
+
+	flags := imgui.TabBarFlagsReorderable | imgui.TabBarFlagsFittingPolicyScroll | imgui.TabBarFlagsTabListPopupButton
+	if imgui.BeginTabBarV("##tabs", flags) {
+		// Closing edits the list, so iterate over a copy
+		for _, doc := range slices.Clone(h.documents) {
+			h.buildTab(doc)
+		}
+		imgui.EndTabBar()
+	}
+
+	h.buildCloseConfirmation()
+}
+
+// buildTab draws one document's tab and, if selected, its contents
+func (h *DocumentHost) buildTab(doc *Document) {
+	var flags imgui.TabItemFlags
+	if doc.Dirty() {
+		flags |= imgui.TabItemFlagsUnsavedDocument
+	}
+	if doc.selectNext {
+		flags |= imgui.TabItemFlagsSetSelected
+		doc.selectNext = false
+	}
+
+	open := true
+	selected := imgui.BeginTabItemV(fmt.Sprintf("%s###doc%d", doc.title, doc.id), &open, flags)
+	if !open {
+		h.requestClose(doc)
+	}
+	if !selected {
+		return
+	}
+
+	h.touch(doc)
+	if doc.layout != nil {
+		for _, widget := range doc.layout() {
+			buildWidget(widget)
+		}
+	}
+	imgui.EndTabItem()
+}
+
+// ImGui's window switcher also listens for Ctrl+Tab, so its keys are parked
+// while a DocumentHost is built and given back once none is
+var (
+	documentHostBuilt  bool
+	navWindowingParked bool
+	navWindowingNext   imgui.KeyChord
+	navWindowingPrev   imgui.KeyChord
+)
+
+// claimCtrlTab takes Ctrl+Tab away from ImGui's window switcher
+func claimCtrlTab() {
+	documentHostBuilt = true
+	if navWindowingParked {
+		return
+	}
+	ctx := imgui.CurrentContext()
+	navWindowingNext, navWindowingPrev = ctx.ConfigNavWindowingKeyNext(), ctx.ConfigNavWindowingKeyPrev()
+	ctx.SetConfigNavWindowingKeyNext(0)
+	ctx.SetConfigNavWindowingKeyPrev(0)
+	navWindowingParked = true
+}
+
+// releaseCtrlTab runs before each frame and restores the window switcher
+// keys if no DocumentHost was built in the previous frame
+func releaseCtrlTab() {
+	if !documentHostBuilt && navWindowingParked {
+		ctx := imgui.CurrentContext()
+		ctx.SetConfigNavWindowingKeyNext(navWindowingNext)
+		ctx.SetConfigNavWindowingKeyPrev(navWindowingPrev)
+		navWindowingParked = false
+	}
+	documentHostBuilt = false
+}
+
+// handleSwitcher implements Ctrl+Tab: each press steps through the
+// documents in most-recently-used order (Shift steps back), and releasing
+// Ctrl selects the highlighted one
+func (h *DocumentHost) handleSwitcher() {
+	io := imgui.CurrentIO()
+	if len(h.mru) > 1 && io.KeyCtrl() && imgui.IsKeyPressedBool(imgui.KeyTab) {
+		step := 1
+		if io.KeyShift() {
+			step = -1
+		}
+		if !h.switching {
+			h.switching = true
+			h.switchTo = 0
+		}
+		h.switchTo = (h.switchTo + step + len(h.mru)) % len(h.mru)
+	}
+
+	if !h.switching {
+		return
+	}
+	if !io.KeyCtrl() || h.switchTo >= len(h.mru) {
+		h.switching = false
+		if h.switchTo < len(h.mru) {
+			h.mru[h.switchTo].Activate()
+		}
+		return
+	}
+
+	viewport := imgui.MainViewport()
+	imgui.SetNextWindowPosV(viewport.Center(), imgui.CondAlways, imgui.Vec2{X: 0.5, Y: 0.5})
+	windowFlags := imgui.WindowFlagsNoDecoration | imgui.WindowFlagsAlwaysAutoResize |
+		imgui.WindowFlagsNoSavedSettings | imgui.WindowFlagsNoFocusOnAppearing | imgui.WindowFlagsNoNav
+	if imgui.BeginV("##switcher"+h.id, nil, windowFlags) {
+		imgui.TextDisabled("Documents")
+		for i, doc := range h.mru {
+			imgui.SelectableBoolV(fmt.Sprintf("%s##switch%d", doc.title, doc.id), i == h.switchTo, 0, imgui.Vec2{})
+		}
+	}
+	imgui.End()
+}
+
+// buildCloseConfirmation asks whether to save a dirty document being closed
+func (h *DocumentHost) buildCloseConfirmation() {
+	const popupID = "Unsaved Changes##close"
+	if h.closing == nil {
+		return
+	}
+	if !imgui.IsPopupOpenStr(popupID) {
+		imgui.OpenPopupStr(popupID)
+	}
+
+	imgui.SetNextWindowPosV(imgui.MainViewport().Center(), imgui.CondAppearing, imgui.Vec2{X: 0.5, Y: 0.5})
+	if !imgui.BeginPopupModalV(popupID, nil, imgui.WindowFlagsAlwaysAutoResize|imgui.WindowFlagsNoSavedSettings) {
+		return
+	}
+	defer imgui.EndPopup()
+
+	doc := h.closing
+	imgui.Text(fmt.Sprintf("Save changes to %s before closing?", doc.title))
+	if h.saveErr != nil {
+		imgui.TextColored(imgui.Vec4{X: 1, Y: 0.4, Z: 0.4, W: 1}, h.saveErr.Error())
+	}
+
+	if doc.onSave != nil {
+		if imgui.Button("Save") {
+			if h.saveErr = doc.Save(); h.saveErr == nil {
+				imgui.CloseCurrentPopup()
+				h.remove(doc)
+				return
+			}
+		}
+		imgui.SetItemDefaultFocus()
+		imgui.SameLine()
+	}
+	if imgui.Button("Don't Save") {
+		imgui.CloseCurrentPopup()
+		h.remove(doc)
+		return
+	}
+	imgui.SameLine()
+	if imgui.Button("Cancel") || imgui.IsKeyPressedBool(imgui.KeyEscape) {
+		imgui.CloseCurrentPopup()
+		h.closing = nil
+	}
+}
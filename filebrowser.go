@@ -0,0 +1,575 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// fileEntry is one item of a directory listing
+type fileEntry struct {
+	name    string
+	path    string
+	isDir   bool
+	size    int64
+	modTime time.Time
+}
+
+// dirListing is a directory's contents, read in the background
+type dirListing struct {
+	path    string
+	entries []fileEntry
+	err     error
+	loading bool
+}
+
+// readDirListing reads a directory, folders first and then by name
+func readDirListing(path string) dirListing {
+	dirEntries, err := os.ReadDir(path)
+	if err != nil {
+		return dirListing{path: path, err: err}
+	}
+
+	entries := make([]fileEntry, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		entry := fileEntry{
+			name:  dirEntry.Name(),
+			path:  filepath.Join(path, dirEntry.Name()),
+			isDir: dirEntry.IsDir(),
+		}
+		if info, err := dirEntry.Info(); err == nil {
+			entry.size = info.Size()
+			entry.modTime = info.ModTime()
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].isDir != entries[j].isDir {
+			return entries[i].isDir
+		}
+		return strings.ToLower(entries[i].name) < strings.ToLower(entries[j].name)
+	})
+	return dirListing{path: path, entries: entries}
+}
+
+// fileKind is the icon shown for a file, chosen by extension
+type fileKind struct {
+	tag   string
+	color imgui.Vec4
+}
+
+var (
+	folderKind = fileKind{"DIR", imgui.Vec4{X: 0.95, Y: 0.75, Z: 0.3, W: 1}}
+	plainKind  = fileKind{"   ", imgui.Vec4{X: 0.6, Y: 0.6, Z: 0.6, W: 1}}
+	fileKinds  = map[string]fileKind{}
+)
+
+func init() {
+	groups := []struct {
+		kind       fileKind
+		extensions []string
+	}{
+		{fileKind{"IMG", imgui.Vec4{X: 0.4, Y: 0.8, Z: 0.4, W: 1}}, []string{".png", ".jpg", ".jpeg", ".gif", ".bmp", ".svg", ".webp"}},
+		{fileKind{"TXT", imgui.Vec4{X: 0.8, Y: 0.8, Z: 0.8, W: 1}}, []string{".txt", ".md", ".log", ".csv", ".rtf"}},
+		{fileKind{"SRC", imgui.Vec4{X: 0.4, Y: 0.7, Z: 1.0, W: 1}}, []string{".go", ".c", ".h", ".cpp", ".py", ".js", ".ts", ".rs", ".java", ".sh"}},
+		{fileKind{"CFG", imgui.Vec4{X: 0.7, Y: 0.5, Z: 1.0, W: 1}}, []string{".json", ".yaml", ".yml", ".toml", ".ini", ".xml", ".conf"}},
+		{fileKind{"ARC", imgui.Vec4{X: 0.9, Y: 0.5, Z: 0.3, W: 1}}, []string{".zip", ".tar", ".gz", ".bz2", ".xz", ".7z", ".rar"}},
+		{fileKind{"AUD", imgui.Vec4{X: 1.0, Y: 0.5, Z: 0.7, W: 1}}, []string{".mp3", ".wav", ".ogg", ".flac"}},
+		{fileKind{"VID", imgui.Vec4{X: 1.0, Y: 0.4, Z: 0.4, W: 1}}, []string{".mp4", ".mkv", ".avi", ".mov", ".webm"}},
+		{fileKind{"DOC", imgui.Vec4{X: 0.3, Y: 0.6, Z: 0.9, W: 1}}, []string{".pdf", ".doc", ".docx", ".odt", ".xls", ".xlsx"}},
+	}
+	for _, group := range groups {
+		for _, extension := range group.extensions {
+			fileKinds[extension] = group.kind
+		}
+	}
+}
+
+// kindOf returns the icon for an entry
+func kindOf(entry fileEntry) fileKind {
+	if entry.isDir {
+		return folderKind
+	}
+	if kind, ok := fileKinds[strings.ToLower(filepath.Ext(entry.name))]; ok {
+		return kind
+	}
+	return plainKind
+}
+
+// formatSize formats a byte count for the size column
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// fileBrowserState holds the directory cache and the selection
+type fileBrowserState struct {
+	dirs     map[string]*dirListing
+	current  string
+	selected []string
+	anchor   int // index of the last plain click, for Shift range selection
+	filter   string
+
+	// Readers never block on the UI: they leave their listing here and exit.
+	// Bumping the generation makes the UI drop reads still in flight.
+	mu         sync.Mutex
+	finished   []dirListing
+	generation int
+}
+
+func (s *fileBrowserState) Dispose() {
+	s.discardPending()
+}
+
+func (s *fileBrowserState) Snapshot() any {
+	return map[string]any{
+		"current":  s.current,
+		"selected": append([]string(nil), s.selected...),
+		"filter":   s.filter,
+	}
+}
+
+// request starts reading a directory unless it is cached or already loading
+func (s *fileBrowserState) request(path string) *dirListing {
+	if listing, ok := s.dirs[path]; ok {
+		return listing
+	}
+
+	listing := &dirListing{path: path, loading: true}
+	s.dirs[path] = listing
+
+	s.mu.Lock()
+	generation := s.generation
+	s.mu.Unlock()
+
+	go func() {
+		listing := readDirListing(path)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.generation == generation {
+			s.finished = append(s.finished, listing)
+		}
+	}()
+	return listing
+}
+
+// receive stores the listings finished since the last frame
+func (s *fileBrowserState) receive() {
+	s.mu.Lock()
+	finished := s.finished
+	s.finished = nil
+	s.mu.Unlock()
+
+	for i := range finished {
+		s.dirs[finished[i].path] = &finished[i]
+	}
+}
+
+// discardPending forgets reads still in flight; directories that are still
+// shown are requested again on the next frame
+func (s *fileBrowserState) discardPending() {
+	s.mu.Lock()
+	s.generation++
+	s.finished = nil
+	s.mu.Unlock()
+
+	for path, listing := range s.dirs {
+		if listing.loading {
+			delete(s.dirs, path)
+		}
+	}
+}
+
+// selectedFiles returns the selected paths that are not directories
+func (s *fileBrowserState) selectedFiles() []string {
+	var files []string
+	for _, path := range s.selected {
+		if listing, ok := s.dirs[filepath.Dir(path)]; ok {
+			for _, entry := range listing.entries {
+				if entry.path == path && !entry.isDir {
+					files = append(files, path)
+					break
+				}
+			}
+		}
+	}
+	return files
+}
+
+// open shows path in the detail list, abandoning reads for the old one
+func (s *fileBrowserState) open(path string) {
+	if path != s.current {
+		s.discardPending()
+	}
+	s.current = path
+	s.anchor = -1
+}
+
+func (s *fileBrowserState) isSelected(path string) bool {
+	for _, selected := range s.selected {
+		if selected == path {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *fileBrowserState) toggle(path string) {
+	for i, selected := range s.selected {
+		if selected == path {
+			s.selected = append(s.selected[:i], s.selected[i+1:]...)
+			return
+		}
+	}
+	s.selected = append(s.selected, path)
+}
+
+// FileBrowserWidget shows a directory tree next to the contents of the
+// selected directory. Directories are read on a background goroutine.
+type FileBrowserWidget struct {
+	id          string
+	root        string
+	extensions  []string
+	multiSelect bool
+	showHidden  bool
+	height      float32
+	onSelect    func(paths []string)
+	onOpen      func(path string)
+}
+
+// FileBrowser creates a browser for the files under root
+func FileBrowser(id, root string) *FileBrowserWidget {
+	return &FileBrowserWidget{
+		id:     fmt.Sprintf("##filebrowser_%s", id),
+		root:   filepath.Clean(root),
+		height: 300,
+	}
+}
+
+// Extensions only lists files with one of the extensions, e.g. ".png" (builder pattern)
+func (f *FileBrowserWidget) Extensions(extensions ...string) *FileBrowserWidget {
+	f.extensions = extensions
+	return f
+}
+
+// MultiSelect allows selecting several files with Ctrl and Shift clicks (builder pattern)
+func (f *FileBrowserWidget) MultiSelect(multiSelect bool) *FileBrowserWidget {
+	f.multiSelect = multiSelect
+	return f
+}
+
+// ShowHidden lists dot files (builder pattern)
+func (f *FileBrowserWidget) ShowHidden(showHidden bool) *FileBrowserWidget {
+	f.showHidden = showHidden
+	return f
+}
+
+// Height sets the height of the browser (builder pattern)
+func (f *FileBrowserWidget) Height(height float32) *FileBrowserWidget {
+	f.height = height
+	return f
+}
+
+// OnSelect sets the callback invoked when the selection changes (builder pattern)
+func (f *FileBrowserWidget) OnSelect(onSelect func(paths []string)) *FileBrowserWidget {
+	f.onSelect = onSelect
+	return f
+}
+
+// OnOpen sets the callback invoked when a file is double-clicked (builder pattern)
+func (f *FileBrowserWidget) OnOpen(onOpen func(path string)) *FileBrowserWidget {
+	f.onOpen = onOpen
+	return f
+}
+
+// Selected returns the selected paths
+func (f *FileBrowserWidget) Selected() []string {
+	return append([]string(nil), f.getState().selected...)
+}
+
+// Current returns the directory shown in the detail list
+func (f *FileBrowserWidget) Current() string {
+	return f.getState().current
+}
+
+func (f *FileBrowserWidget) getState() *fileBrowserState {
+	if existingState, exists := GlobalContext.stateMap[f.id]; exists {
+		if state, ok := existingState.(*fileBrowserState); ok {
+			return state
+		}
+	}
+
+	newState := &fileBrowserState{
+		dirs:    make(map[string]*dirListing),
+		current: f.root,
+		anchor:  -1,
+	}
+	GlobalContext.stateMap[f.id] = newState
+	return newState
+}
+
+// visible reports whether an entry passes the hidden, extension and text filters
+func (f *FileBrowserWidget) visible(entry fileEntry, filter string) bool {
+	if !f.showHidden && strings.HasPrefix(entry.name, ".") {
+		return false
+	}
+	if entry.isDir {
+		return filter == "" || strings.Contains(strings.ToLower(entry.name), filter)
+	}
+	if len(f.extensions) > 0 {
+		matched := false
+		for _, extension := range f.extensions {
+			if strings.EqualFold(filepath.Ext(entry.name), extension) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return filter == "" || strings.Contains(strings.ToLower(entry.name), filter)
+}
+
+func (f *FileBrowserWidget) Build() {
+	state := f.getState()
+	state.receive()
+
+	imgui.PushIDStr(f.id)
+	defer imgui.PopID()
+
+	flags := imgui.TableFlagsResizable | imgui.TableFlagsBordersInnerV
+	if !imgui.BeginTableV("##panes", 2, flags, imgui.Vec2{Y: f.height}, 0) {
+		return
+	}
+	imgui.TableSetupColumnV("##tree", imgui.TableColumnFlagsWidthStretch, 1, 0)
+	imgui.TableSetupColumnV("##detail", imgui.TableColumnFlagsWidthStretch, 3, 0)
+	imgui.TableNextRow()
+
+	imgui.TableNextColumn()
+	if imgui.BeginChildStrV("##tree", imgui.Vec2{}, imgui.ChildFlagsNone, 0) {
+		f.buildTree(state, f.root)
+	}
+	imgui.EndChild()
+
+	imgui.TableNextColumn()
+	f.buildDetail(state)
+
+	imgui.EndTable()
+}
+
+// buildTree draws a directory node, reading its children once it is opened
+func (f *FileBrowserWidget) buildTree(state *fileBrowserState, path string) {
+	name := filepath.Base(path)
+	if path == f.root {
+		name = path
+	}
+
+	flags := imgui.TreeNodeFlagsOpenOnArrow | imgui.TreeNodeFlagsOpenOnDoubleClick | imgui.TreeNodeFlagsSpanAvailWidth
+	if path == state.current {
+		flags |= imgui.TreeNodeFlagsSelected
+	}
+	if path == f.root {
+		flags |= imgui.TreeNodeFlagsDefaultOpen
+	}
+
+	open := imgui.TreeNodeExStrV(name+"##"+path, flags)
+	if imgui.IsItemClicked() && !imgui.IsItemToggledOpen() {
+		state.open(path)
+	}
+	if !open {
+		return
+	}
+	defer imgui.TreePop()
+
+	listing := state.request(path)
+	if listing.loading {
+		imgui.TextDisabled("Loading...")
+		return
+	}
+	for _, entry := range listing.entries {
+		if entry.isDir && (f.showHidden || !strings.HasPrefix(entry.name, ".")) {
+			f.buildTree(state, entry.path)
+		}
+	}
+}
+
+// buildDetail draws the filter and the contents of the current directory
+func (f *FileBrowserWidget) buildDetail(state *fileBrowserState) {
+	if state.current != f.root {
+		if imgui.SmallButton("Up") {
+			state.open(filepath.Dir(state.current))
+		}
+		imgui.SameLine()
+	}
+	if imgui.SmallButton("Refresh") {
+		delete(state.dirs, state.current)
+	}
+	imgui.SameLine()
+	imgui.SetNextItemWidth(-1)
+	imgui.InputTextWithHint("##filter", "Filter", &state.filter, 0, nil)
+
+	listing := state.request(state.current)
+	switch {
+	case listing.loading:
+		imgui.TextDisabled("Loading...")
+		return
+	case listing.err != nil:
+		imgui.TextColored(imgui.Vec4{X: 1, Y: 0.4, Z: 0.4, W: 1}, listing.err.Error())
+		return
+	}
+
+	filter := strings.ToLower(state.filter)
+	entries := make([]fileEntry, 0, len(listing.entries))
+	for _, entry := range listing.entries {
+		if f.visible(entry, filter) {
+			entries = append(entries, entry)
+		}
+	}
+
+	flags := imgui.TableFlagsScrollY | imgui.TableFlagsRowBg | imgui.TableFlagsResizable | imgui.TableFlagsBordersOuterH
+	if !imgui.BeginTableV("##entries", 3, flags, imgui.Vec2{}, 0) {
+		return
+	}
+	imgui.TableSetupScrollFreeze(0, 1)
+	imgui.TableSetupColumnV("Name", imgui.TableColumnFlagsWidthStretch, 0, 0)
+	imgui.TableSetupColumnV("Size", imgui.TableColumnFlagsWidthFixed, 80, 0)
+	imgui.TableSetupColumnV("Modified", imgui.TableColumnFlagsWidthFixed, 130, 0)
+	imgui.TableHeadersRow()
+
+	for i, entry := range entries {
+		imgui.TableNextRow()
+		imgui.TableNextColumn()
+
+		kind := kindOf(entry)
+		imgui.TextColored(kind.color, kind.tag)
+		imgui.SameLine()
+
+		selectableFlags := imgui.SelectableFlagsSpanAllColumns | imgui.SelectableFlagsAllowDoubleClick
+		if imgui.SelectableBoolV(entry.name+"##"+entry.path, state.isSelected(entry.path), selectableFlags, imgui.Vec2{}) {
+			f.click(state, entries, i)
+		}
+		if imgui.IsItemHovered() && imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) {
+			if entry.isDir {
+				state.open(entry.path)
+			} else if f.onOpen != nil {
+				f.onOpen(entry.path)
+			}
+		}
+
+		imgui.TableNextColumn()
+		if !entry.isDir {
+			imgui.Text(formatSize(entry.size))
+		}
+		imgui.TableNextColumn()
+		if !entry.modTime.IsZero() {
+			imgui.Text(entry.modTime.Format("2006-01-02 15:04"))
+		}
+	}
+
+	imgui.EndTable()
+}
+
+// click updates the selection: plain clicks select one entry, Ctrl toggles
+// and Shift extends from the last plain click
+func (f *FileBrowserWidget) click(state *fileBrowserState, entries []fileEntry, index int) {
+	io := imgui.CurrentIO()
+	path := entries[index].path
+
+	switch {
+	case f.multiSelect && io.KeyShift() && state.anchor >= 0 && state.anchor < len(entries):
+		from, to := state.anchor, index
+		if from > to {
+			from, to = to, from
+		}
+		state.selected = state.selected[:0]
+		for _, entry := range entries[from : to+1] {
+			state.selected = append(state.selected, entry.path)
+		}
+	case f.multiSelect && io.KeyCtrl():
+		state.toggle(path)
+		state.anchor = index
+	default:
+		state.selected = []string{path}
+		state.anchor = index
+	}
+
+	if f.onSelect != nil {
+		f.onSelect(append([]string(nil), state.selected...))
+	}
+}
+
+// FileDialogResult is what OpenFileDialog delivers once the dialog closes
+type FileDialogResult struct {
+	Paths []string
+	OK    bool // false if the user cancelled
+}
+
+// Number of file dialogs opened, keeping their browser states apart
+var fileDialogCount atomic.Int64
+
+// OpenFileDialog lets the user pick files under root in a modal drawn with
+// FileBrowser, as a fallback where no native dialog is available. Open or a
+// double-click accepts; Cancel or Escape gives OK false. Safe to call from
+// any goroutine.
+func OpenFileDialog(title, root string, multiSelect bool, extensions ...string) <-chan FileDialogResult {
+	result := make(chan FileDialogResult, 1)
+
+	var opened string
+	browser := FileBrowser(fmt.Sprintf("dialog%d", fileDialogCount.Add(1)), root).
+		MultiSelect(multiSelect).
+		Extensions(extensions...).
+		Height(360).
+		OnOpen(func(path string) { opened = path })
+
+	finish := func(dialogResult FileDialogResult) bool {
+		if state, ok := GlobalContext.stateMap[browser.id].(*fileBrowserState); ok {
+			state.Dispose()
+			delete(GlobalContext.stateMap, browser.id)
+		}
+		result <- dialogResult
+		return true
+	}
+
+	enqueueDialog(title, func() bool {
+		// Auto-resizing modals have no width of their own to stretch into
+		imgui.Dummy(imgui.Vec2{X: 640})
+		browser.Build()
+		if opened != "" {
+			return finish(FileDialogResult{Paths: []string{opened}, OK: true})
+		}
+
+		files := browser.getState().selectedFiles()
+
+		imgui.Separator()
+		imgui.BeginDisabledV(len(files) == 0)
+		open := imgui.Button("Open")
+		imgui.EndDisabled()
+		if open {
+			return finish(FileDialogResult{Paths: files, OK: true})
+		}
+		imgui.SameLine()
+		if imgui.Button("Cancel") || imgui.IsKeyPressedBool(imgui.KeyEscape) {
+			return finish(FileDialogResult{})
+		}
+		return false
+	})
+	return result
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"runtime"
+	"unsafe"
+
+	"github.com/AllenDang/cimgui-go/backend"
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// defaultFontSize is the size of Dear ImGui's built-in font
+const defaultFontSize = 13
+
+// FontHinting selects how FreeType fits glyph outlines to the pixel grid
+type FontHinting int
+
+const (
+	HintingDefault FontHinting = iota // FreeType's native hinter
+	HintingNone                       // unhinted, closest to the design
+	HintingLight                      // vertical only, sharp but keeps shapes
+	HintingMono                       // strong, for monochrome output
+	HintingAuto                       // FreeType's auto-hinter
+)
+
+// builderFlags returns the ImGuiFreeTypeBuilderFlags for the hinting mode
+func (h FontHinting) builderFlags() uint32 {
+	switch h {
+	case HintingNone:
+		return 1 << 0 // NoHinting
+	case HintingLight:
+		return 1 << 3 // LightHinting
+	case HintingMono:
+		return 1 << 4 // MonoHinting
+	case HintingAuto:
+		return 1 << 2 // ForceAutoHint
+	default:
+		return 0
+	}
+}
+
+// Font is a font added to the FontManager, rasterized at the monitor's
+// scale
+type Font struct {
+	path string
+	size float32
+	font *imgui.Font
+}
+
+// FontManager owns the font atlas. When the window moves to a monitor
+// with a different scale factor it rasterizes the fonts again at the new
+// scale, so text stays crisp.
+type FontManager struct {
+	fonts   []*Font
+	scale   float32
+	dirty   bool
+	texture *backend.Texture
+
+	// Rasterizer options applied to every font
+	hinting    FontHinting
+	oversample int
+
+	onScaleChange []func(scale float32)
+}
+
+// Fonts returns the window's font manager
+func (w *MasterWindow) Fonts() *FontManager {
+	return w.fonts
+}
+
+// AddFont adds a TrueType font at the given size in unscaled pixels. The
+// first font added replaces the built-in one as the default.
+func (m *FontManager) AddFont(path string, size float32) *Font {
+	font := &Font{path: path, size: size}
+	m.fonts = append(m.fonts, font)
+	m.dirty = true
+	return font
+}
+
+// Hinting sets the FreeType hinting mode. It applies when cimgui-go is
+// built with the FreeType rasterizer, which Dear ImGui then uses instead of
+// stb_truetype; stb_truetype ignores it (builder pattern)
+func (m *FontManager) Hinting(hinting FontHinting) *FontManager {
+	m.hinting = hinting
+	m.dirty = true
+	return m
+}
+
+// SubPixel rasterizes glyphs at oversample horizontal sub-pixel offsets and
+// places them without snapping to whole pixels, giving smoother spacing
+// for small text with stb_truetype. 0 keeps Dear ImGui's default (builder
+// pattern)
+func (m *FontManager) SubPixel(oversample int) *FontManager {
+	m.oversample = oversample
+	m.dirty = true
+	return m
+}
+
+// fontConfig returns a config with the rasterizer options for a font of
+// the given size; the caller destroys it
+func (m *FontManager) fontConfig(size float32) *imgui.FontConfig {
+	config := imgui.NewFontConfig()
+	config.SetSizePixels(size)
+	config.SetFontBuilderFlags(m.hinting.builderFlags())
+	if m.oversample > 0 {
+		config.SetOversampleH(int32(m.oversample))
+		config.SetPixelSnapH(false)
+	}
+	return config
+}
+
+// Scale returns the scale factor the fonts are rasterized for
+func (m *FontManager) Scale() float32 {
+	return m.scale
+}
+
+// OnScaleChange registers a callback invoked with the new scale factor
+// after the fonts were rebuilt for it, for apps that cache text sizes
+func (m *FontManager) OnScaleChange(callback func(scale float32)) *FontManager {
+	m.onScaleChange = append(m.onScaleChange, callback)
+	return m
+}
+
+// update rebuilds the atlas if fonts were added or the monitor scale
+// changed. The atlas is locked during a frame, so this runs before it.
+func (m *FontManager) update(w *MasterWindow) {
+	scale, _ := w.backend.ContentScale()
+	if scale <= 0 {
+		scale = 1
+	}
+	scaleChanged := m.scale != 0 && scale != m.scale
+	if !m.dirty && scale == m.scale {
+		return
+	}
+
+	m.scale = scale
+	m.dirty = false
+	m.rebuild()
+	if scaleChanged {
+		for _, callback := range m.onScaleChange {
+			callback(scale)
+		}
+	}
+}
+
+// rebuild rasterizes the fonts at the current scale and uploads the atlas
+func (m *FontManager) rebuild() {
+	io := imgui.CurrentIO()
+	atlas := io.Fonts()
+	atlas.Clear()
+
+	// ImGui aborts on a font file it can't open, so missing files are
+	// skipped and the built-in font keeps the atlas usable
+	var available []*Font
+	for _, font := range m.fonts {
+		font.font = nil
+		if err := checkFontFile(font.path); err != nil {
+			ReportDiagnostic(err.Error())
+			continue
+		}
+		available = append(available, font)
+	}
+
+	if len(available) == 0 {
+		config := m.fontConfig(defaultFontSize * m.scale)
+		atlas.AddFontDefaultV(config)
+		config.Destroy()
+	}
+	for _, font := range available {
+		config := m.fontConfig(font.size * m.scale)
+		font.font = atlas.AddFontFromFileTTFV(font.path, font.size*m.scale, config, nil)
+		config.Destroy()
+	}
+	atlas.Build()
+
+	// macOS scales the framebuffer instead of the window, so the larger
+	// glyphs are drawn at their logical size
+	if runtime.GOOS == "darwin" {
+		io.SetFontGlobalScale(1 / m.scale)
+	}
+
+	pixels, width, height, _ := atlas.GetTextureDataAsRGBA32()
+	rgba := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	copy(rgba.Pix, unsafe.Slice((*byte)(pixels), len(rgba.Pix)))
+	if m.texture != nil {
+		m.texture.Release()
+	}
+	m.texture = backend.NewTextureFromRgba(rgba)
+	atlas.SetTexID(m.texture.ID)
+}
+
+// checkFontFile makes sure path is a readable, non-empty file
+func checkFontFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("font %s: %w", path, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	switch {
+	case err != nil:
+		return fmt.Errorf("font %s: %w", path, err)
+	case info.IsDir():
+		return fmt.Errorf("font %s: is a directory", path)
+	case info.Size() == 0:
+		return fmt.Errorf("font %s: empty file", path)
+	}
+	return nil
+}
+
+// FontWidget builds its widgets with a font added to the FontManager
+type FontWidget struct {
+	font    *Font
+	widgets []Widget
+}
+
+// WithFont creates a widget building widgets with font
+func WithFont(font *Font, widgets ...Widget) *FontWidget {
+	return &FontWidget{font: font, widgets: widgets}
+}
+
+func (f *FontWidget) Build() {
+	// The font is nil until the atlas is first built, or if its file is missing
+	if f.font.font != nil {
+		imgui.PushFont(f.font.font)
+		defer imgui.PopFont()
+	}
+	for _, widget := range f.widgets {
+		buildWidget(widget)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// formState collects the inputs of a form being built
+type formState struct {
+	values  map[string]string
+	invalid bool
+}
+
+// currentForm is the form whose widgets are being built, or nil
+var currentForm *formState
+
+// Validate adds checks run on the text every frame, in the order added;
+// the first error is shown under the field. Inside a Form, a failing
+// check disables the OK button (builder pattern)
+func (i *InputTextWidget) Validate(validators ...func(text string) error) *InputTextWidget {
+	i.validators = append(i.validators, validators...)
+	return i
+}
+
+// validate runs the checks, showing the first error, and records the
+// field in the enclosing form
+func (i *InputTextWidget) validate() {
+	if currentForm != nil {
+		currentForm.values[i.label] = *i.text
+	}
+
+	for _, validator := range i.validators {
+		if err := validator(*i.text); err != nil {
+			imgui.TextColored(imgui.Vec4{X: 1, Y: 0.4, Z: 0.4, W: 1}, err.Error())
+			if currentForm != nil {
+				currentForm.invalid = true
+			}
+			return
+		}
+	}
+}
+
+// FormResult is the outcome of a Form dialog
+type FormResult struct {
+	OK bool // false if the user cancelled
+	// Values maps each InputText's label to its text when the form closed
+	Values map[string]string
+}
+
+// FormDialog is a queued dialog holding input widgets; configure it right
+// after creating it
+type FormDialog struct {
+	widgets  []Widget
+	onResult func(result FormResult)
+}
+
+// Form queues a dialog showing widgets above OK and Cancel buttons. OK
+// stays disabled while any InputText in the form fails its Validate
+// checks. Safe to call from any goroutine.
+func Form(title string, widgets ...Widget) *FormDialog {
+	f := &FormDialog{widgets: widgets}
+	enqueueDialog(title, f.build)
+	return f
+}
+
+// ResultCallback sets the callback invoked on the UI thread when the form
+// is closed (builder pattern)
+func (f *FormDialog) ResultCallback(onResult func(result FormResult)) *FormDialog {
+	dialogMu.Lock()
+	defer dialogMu.Unlock()
+	f.onResult = onResult
+	return f
+}
+
+// build draws the form, reporting whether it was closed
+func (f *FormDialog) build() bool {
+	dialogMu.Lock()
+	onResult := f.onResult
+	dialogMu.Unlock()
+
+	form := &formState{values: make(map[string]string)}
+	outer := currentForm
+	currentForm = form
+	for _, widget := range f.widgets {
+		buildWidget(widget)
+	}
+	currentForm = outer
+
+	imgui.Separator()
+	imgui.BeginDisabledV(form.invalid)
+	ok := imgui.Button("OK")
+	imgui.EndDisabled()
+	imgui.SameLine()
+	cancel := imgui.Button("Cancel") || imgui.IsKeyPressedBool(imgui.KeyEscape)
+	if !ok && !cancel {
+		return false
+	}
+
+	if onResult != nil {
+		onResult(FormResult{OK: ok, Values: form.values})
+	}
+	return true
+}
@@ -0,0 +1,31 @@
+package main
+
+// The backend doesn't expose GLFW's focus calls, but GLFW is linked in
+// through it, so they can be declared and called directly.
+
+/*
+typedef struct GLFWwindow GLFWwindow;
+
+GLFWwindow* glfwGetCurrentContext(void);
+int glfwGetWindowAttrib(GLFWwindow* window, int attrib);
+void glfwRestoreWindow(GLFWwindow* window);
+void glfwShowWindow(GLFWwindow* window);
+void glfwFocusWindow(GLFWwindow* window);
+
+#define GLFW_ICONIFIED 0x00020002
+*/
+import "C"
+
+// focusCurrentWindow restores, shows and focuses the window whose context
+// is current, which on the UI thread is the master window
+func focusCurrentWindow() {
+	window := C.glfwGetCurrentContext()
+	if window == nil {
+		return
+	}
+	if C.glfwGetWindowAttrib(window, C.GLFW_ICONIFIED) != 0 {
+		C.glfwRestoreWindow(window)
+	}
+	C.glfwShowWindow(window)
+	C.glfwFocusWindow(window)
+}
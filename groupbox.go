@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// groupBoxState remembers whether a collapsible group box is open
+type groupBoxState struct {
+	open bool
+}
+
+func (s *groupBoxState) Dispose() {
+	// Nothing to clean up
+}
+
+func (s *groupBoxState) Snapshot() any {
+	return map[string]any{"open": s.open}
+}
+
+// GroupBoxWidget draws a titled border around its children, the classic
+// way of grouping form fields. The border uses the theme's border color.
+type GroupBoxWidget struct {
+	title       string
+	widgets     []Widget
+	collapsible bool
+	defaultOpen bool
+	height      float32
+	overflow    Overflow
+}
+
+// GroupBox creates a group box with the given title
+func GroupBox(title string) *GroupBoxWidget {
+	return &GroupBoxWidget{title: title, defaultOpen: true}
+}
+
+// Layout sets the widgets inside the box (builder pattern)
+func (g *GroupBoxWidget) Layout(widgets ...Widget) *GroupBoxWidget {
+	g.widgets = widgets
+	return g
+}
+
+// Collapsible lets the title toggle the contents; defaultOpen is the
+// initial state (builder pattern)
+func (g *GroupBoxWidget) Collapsible(collapsible, defaultOpen bool) *GroupBoxWidget {
+	g.collapsible = collapsible
+	g.defaultOpen = defaultOpen
+	return g
+}
+
+func (g *GroupBoxWidget) getState() *groupBoxState {
+	id := fmt.Sprintf("##groupbox_%d", imgui.IDStr(g.title))
+	if existingState, exists := GlobalContext.stateMap[id]; exists {
+		if state, ok := existingState.(*groupBoxState); ok {
+			return state
+		}
+	}
+
+	newState := &groupBoxState{open: g.defaultOpen}
+	GlobalContext.stateMap[id] = newState
+	return newState
+}
+
+func (g *GroupBoxWidget) Build() {
+	style := imgui.CurrentStyle()
+	padding := style.FramePadding()
+	lineHeight := imgui.TextLineHeight()
+	drawList := imgui.WindowDrawList()
+
+	pos := imgui.CursorScreenPos()
+	width := imgui.ContentRegionAvail().X
+	textColor := imgui.ColorU32Col(imgui.ColText)
+
+	open := true
+	titleX := pos.X + padding.X*2
+	if g.collapsible {
+		state := g.getState()
+		arrowSize := lineHeight * 0.5
+
+		// The title row acts as the toggle
+		if imgui.InvisibleButton("##groupbox_"+g.title, imgui.Vec2{X: width, Y: lineHeight}) {
+			state.open = !state.open
+		}
+		open = state.open
+
+		center := imgui.Vec2{X: titleX + arrowSize/2, Y: pos.Y + lineHeight/2}
+		if open {
+			drawList.AddTriangleFilled(
+				imgui.Vec2{X: center.X - arrowSize/2, Y: center.Y - arrowSize/3},
+				imgui.Vec2{X: center.X + arrowSize/2, Y: center.Y - arrowSize/3},
+				imgui.Vec2{X: center.X, Y: center.Y + arrowSize/2}, textColor)
+		} else {
+			drawList.AddTriangleFilled(
+				imgui.Vec2{X: center.X - arrowSize/3, Y: center.Y - arrowSize/2},
+				imgui.Vec2{X: center.X + arrowSize/2, Y: center.Y},
+				imgui.Vec2{X: center.X - arrowSize/3, Y: center.Y + arrowSize/2}, textColor)
+		}
+		titleX += arrowSize + style.ItemSpacing().X/2
+	} else {
+		imgui.Dummy(imgui.Vec2{X: width, Y: lineHeight})
+	}
+
+	drawList.AddTextVec2(imgui.Vec2{X: titleX, Y: pos.Y}, textColor, g.title)
+	titleEnd := titleX + imgui.CalcTextSize(g.title).X
+
+	// A collapsed box is only its title with a rule after it
+	borderColor := imgui.ColorU32Col(imgui.ColBorder)
+	top := pos.Y + lineHeight/2
+	if !open {
+		drawList.AddLine(imgui.Vec2{X: titleEnd + padding.X, Y: top}, imgui.Vec2{X: pos.X + width, Y: top}, borderColor)
+		return
+	}
+
+	imgui.IndentV(padding.X * 2)
+	imgui.BeginGroup()
+	if g.height > 0 {
+		// Leave room for the right border
+		size := imgui.Vec2{X: -padding.X * 2, Y: g.height}
+		buildOverflow("##groupbox_content_"+g.title, g.overflow, size, g.widgets)
+	} else {
+		for _, widget := range g.widgets {
+			buildWidget(widget)
+		}
+	}
+	imgui.EndGroup()
+	imgui.UnindentV(padding.X * 2)
+	imgui.Dummy(imgui.Vec2{Y: padding.Y})
+	bottom := imgui.ItemRectMax().Y
+
+	// The top edge is broken where the title sits
+	left, right := pos.X, pos.X+width-1
+	drawList.AddLine(imgui.Vec2{X: left, Y: top}, imgui.Vec2{X: titleX - padding.X, Y: top}, borderColor)
+	drawList.AddLine(imgui.Vec2{X: titleEnd + padding.X, Y: top}, imgui.Vec2{X: right, Y: top}, borderColor)
+	drawList.AddLine(imgui.Vec2{X: left, Y: top}, imgui.Vec2{X: left, Y: bottom}, borderColor)
+	drawList.AddLine(imgui.Vec2{X: right, Y: top}, imgui.Vec2{X: right, Y: bottom}, borderColor)
+	drawList.AddLine(imgui.Vec2{X: left, Y: bottom}, imgui.Vec2{X: right, Y: bottom}, borderColor)
+}
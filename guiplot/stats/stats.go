@@ -0,0 +1,212 @@
+// Package stats holds the aggregation helpers dashboards feed into plot
+// widgets: histogram binning, percentiles and moving averages.
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// Bin is one histogram bucket covering [Min, Max)
+type Bin struct {
+	Min   float64
+	Max   float64
+	Count int
+}
+
+// Center returns the middle of the bin, where bar plots place it
+func (b Bin) Center() float64 {
+	return (b.Min + b.Max) / 2
+}
+
+// Histogram sorts values into count equal-width bins spanning their range.
+// The last bin includes its upper edge so the maximum is counted. NaNs and
+// infinities are skipped.
+func Histogram(values []float64, count int) []Bin {
+	low, high, ok := bounds(values)
+	if !ok || count <= 0 {
+		return nil
+	}
+	if low == high {
+		// A single distinct value still gets a bin of unit width
+		low, high = low-0.5, high+0.5
+	}
+	return HistogramRange(values, count, low, high)
+}
+
+// HistogramRange sorts values into count equal-width bins over [low, high];
+// values outside the range are ignored. The range must be finite.
+func HistogramRange(values []float64, count int, low, high float64) []Bin {
+	if count <= 0 || !(high > low) || math.IsInf(low, 0) || math.IsInf(high, 0) {
+		return nil
+	}
+
+	width := (high - low) / float64(count)
+	bins := make([]Bin, count)
+	for i := range bins {
+		bins[i].Min = low + float64(i)*width
+		bins[i].Max = low + float64(i+1)*width
+	}
+	bins[count-1].Max = high
+
+	for _, value := range values {
+		if !isFinite(value) || value < low || value > high {
+			continue
+		}
+		index := int((value - low) / width)
+		if index >= count {
+			index = count - 1
+		}
+		bins[index].Count++
+	}
+	return bins
+}
+
+// SturgesBins suggests a bin count for n values using Sturges' rule
+func SturgesBins(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return int(math.Ceil(math.Log2(float64(n)))) + 1
+}
+
+// Counts returns the bin counts as floats, the form bar plots take
+func Counts(bins []Bin) []float64 {
+	counts := make([]float64, len(bins))
+	for i, bin := range bins {
+		counts[i] = float64(bin.Count)
+	}
+	return counts
+}
+
+// Percentile returns the p-th percentile (0 to 100) of values using linear
+// interpolation between the closest ranks. NaNs are skipped; an empty input
+// returns NaN.
+func Percentile(values []float64, p float64) float64 {
+	return Percentiles(values, p)[0]
+}
+
+// Percentiles returns several percentiles at once, sorting the data only once
+func Percentiles(values []float64, ps ...float64) []float64 {
+	sorted := make([]float64, 0, len(values))
+	for _, value := range values {
+		if !math.IsNaN(value) {
+			sorted = append(sorted, value)
+		}
+	}
+	sort.Float64s(sorted)
+
+	results := make([]float64, len(ps))
+	for i, p := range ps {
+		results[i] = percentileSorted(sorted, p)
+	}
+	return results
+}
+
+// percentileSorted interpolates the p-th percentile of sorted values
+func percentileSorted(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+
+	p = math.Max(0, math.Min(100, p))
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	fraction := rank - float64(lower)
+	return sorted[lower] + (sorted[upper]-sorted[lower])*fraction
+}
+
+// Median returns the 50th percentile of values
+func Median(values []float64) float64 {
+	return Percentile(values, 50)
+}
+
+// Mean returns the arithmetic mean of values. NaNs are skipped; an input
+// with no other values returns NaN.
+func Mean(values []float64) float64 {
+	sum, n := 0.0, 0
+	for _, value := range values {
+		if !math.IsNaN(value) {
+			sum += value
+			n++
+		}
+	}
+	if n == 0 {
+		return math.NaN()
+	}
+	return sum / float64(n)
+}
+
+// MovingAverage returns the mean of each value and the window-1 values
+// before it. The first values average over what is available, so the
+// result has the same length as the input. NaNs are skipped; a window
+// holding only NaNs averages to NaN.
+func MovingAverage(values []float64, window int) []float64 {
+	if window <= 0 {
+		return nil
+	}
+
+	averages := make([]float64, len(values))
+	sum, n := 0.0, 0
+	for i, value := range values {
+		if !math.IsNaN(value) {
+			sum += value
+			n++
+		}
+		if i >= window {
+			if dropped := values[i-window]; !math.IsNaN(dropped) {
+				sum -= dropped
+				n--
+			}
+		}
+		if n == 0 {
+			averages[i] = math.NaN()
+		} else {
+			averages[i] = sum / float64(n)
+		}
+	}
+	return averages
+}
+
+// ExponentialMovingAverage smooths values with factor alpha in (0, 1];
+// higher alphas follow the data more closely. NaNs are skipped and hold
+// the previous average, which is NaN until the first number.
+func ExponentialMovingAverage(values []float64, alpha float64) []float64 {
+	if len(values) == 0 || alpha <= 0 || alpha > 1 {
+		return nil
+	}
+
+	averages := make([]float64, len(values))
+	average := math.NaN()
+	for i, value := range values {
+		switch {
+		case math.IsNaN(value):
+		case math.IsNaN(average):
+			average = value
+		default:
+			average = alpha*value + (1-alpha)*average
+		}
+		averages[i] = average
+	}
+	return averages
+}
+
+// bounds returns the smallest and largest finite values
+func bounds(values []float64) (low, high float64, ok bool) {
+	low, high = math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		if !isFinite(value) {
+			continue
+		}
+		low = math.Min(low, value)
+		high = math.Max(high, value)
+		ok = true
+	}
+	return low, high, ok
+}
+
+// isFinite reports whether value is neither NaN nor infinite
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
@@ -0,0 +1,211 @@
+package stats
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	tests := []struct {
+		name     string
+		values   []float64
+		count    int
+		counts   []int
+		low      float64
+		high     float64
+		wantNone bool
+	}{
+		{name: "empty", values: nil, count: 4, wantNone: true},
+		{name: "no bins", values: []float64{1, 2}, count: 0, wantNone: true},
+		{name: "only NaN", values: []float64{nan, nan}, count: 2, wantNone: true},
+		{name: "single value", values: []float64{5}, count: 3, counts: []int{0, 1, 0}, low: 4.5, high: 5.5},
+		{name: "all equal", values: []float64{2, 2, 2}, count: 1, counts: []int{3}, low: 1.5, high: 2.5},
+		{name: "NaN skipped", values: []float64{nan, 0, 1}, count: 2, counts: []int{1, 1}, low: 0, high: 1},
+		{name: "maximum counted", values: []float64{0, 1, 2, 3, 4}, count: 4, counts: []int{1, 1, 1, 2}, low: 0, high: 4},
+		{name: "infinities skipped", values: []float64{0, 1, inf, -inf}, count: 2, counts: []int{1, 1}, low: 0, high: 1},
+		{name: "only non-finite", values: []float64{inf, -inf, nan}, count: 2, wantNone: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bins := Histogram(test.values, test.count)
+			if test.wantNone {
+				if bins != nil {
+					t.Fatalf("Histogram() = %v, want nil", bins)
+				}
+				return
+			}
+
+			counts := make([]int, len(bins))
+			for i, bin := range bins {
+				counts[i] = bin.Count
+			}
+			if !slices.Equal(counts, test.counts) {
+				t.Errorf("counts = %v, want %v", counts, test.counts)
+			}
+			if bins[0].Min != test.low || bins[len(bins)-1].Max != test.high {
+				t.Errorf("range = [%v, %v], want [%v, %v]", bins[0].Min, bins[len(bins)-1].Max, test.low, test.high)
+			}
+		})
+	}
+}
+
+func TestHistogramRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    []float64
+		count     int
+		low, high float64
+		counts    []int
+	}{
+		{name: "out of range ignored", values: []float64{-1, 0, 5, 10, 11}, count: 2, low: 0, high: 10, counts: []int{1, 2}},
+		{name: "empty range", values: []float64{1}, count: 2, low: 3, high: 3},
+		{name: "inverted range", values: []float64{1}, count: 2, low: 3, high: 1},
+		{name: "infinite range", values: []float64{1}, count: 2, low: 0, high: math.Inf(1)},
+		{name: "infinite values ignored", values: []float64{math.Inf(-1), 1, math.Inf(1)}, count: 1, low: 0, high: 2, counts: []int{1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bins := HistogramRange(test.values, test.count, test.low, test.high)
+			var counts []int
+			for _, bin := range bins {
+				counts = append(counts, bin.Count)
+			}
+			if !slices.Equal(counts, test.counts) {
+				t.Errorf("counts = %v, want %v", counts, test.counts)
+			}
+		})
+	}
+}
+
+func TestSturgesBins(t *testing.T) {
+	tests := []struct {
+		n, want int
+	}{
+		{0, 1},
+		{1, 1},
+		{2, 2},
+		{8, 4},
+		{100, 8},
+	}
+
+	for _, test := range tests {
+		if got := SturgesBins(test.n); got != test.want {
+			t.Errorf("SturgesBins(%d) = %d, want %d", test.n, got, test.want)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name   string
+		values []float64
+		p      float64
+		want   float64
+	}{
+		{name: "empty", values: nil, p: 50, want: nan},
+		{name: "only NaN", values: []float64{nan}, p: 50, want: nan},
+		{name: "single value", values: []float64{7}, p: 90, want: 7},
+		{name: "median interpolates", values: []float64{4, 1, 3, 2}, p: 50, want: 2.5},
+		{name: "NaN skipped", values: []float64{nan, 1, 3}, p: 50, want: 2},
+		{name: "clamped below", values: []float64{1, 2, 3}, p: -10, want: 1},
+		{name: "clamped above", values: []float64{1, 2, 3}, p: 150, want: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Percentile(test.values, test.p)
+			if math.IsNaN(test.want) {
+				if !math.IsNaN(got) {
+					t.Errorf("Percentile() = %v, want NaN", got)
+				}
+				return
+			}
+			if got != test.want {
+				t.Errorf("Percentile() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMean(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{name: "empty", values: nil, want: nan},
+		{name: "only NaN", values: []float64{nan, nan}, want: nan},
+		{name: "plain", values: []float64{1, 2, 6}, want: 3},
+		{name: "NaN skipped", values: []float64{1, nan, 3}, want: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Mean(test.values); !equalFloats([]float64{got}, []float64{test.want}) {
+				t.Errorf("Mean() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMovingAverage(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name   string
+		values []float64
+		window int
+		want   []float64
+	}{
+		{name: "empty", values: nil, window: 2, want: []float64{}},
+		{name: "no window", values: []float64{1, 2}, window: 0, want: nil},
+		{name: "warm-up", values: []float64{2, 4, 6, 8}, window: 2, want: []float64{2, 3, 5, 7}},
+		{name: "window wider than data", values: []float64{1, 3}, window: 5, want: []float64{1, 2}},
+		{name: "NaN skipped", values: []float64{1, nan, 3, 5}, window: 2, want: []float64{1, 1, 3, 4}},
+		{name: "window of NaN", values: []float64{nan, nan, 2}, window: 2, want: []float64{nan, nan, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := MovingAverage(test.values, test.window)
+			if !equalFloats(got, test.want) || (got == nil) != (test.want == nil) {
+				t.Errorf("MovingAverage() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestExponentialMovingAverage(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name   string
+		values []float64
+		alpha  float64
+		want   []float64
+	}{
+		{name: "empty", values: nil, alpha: 0.5, want: nil},
+		{name: "bad alpha", values: []float64{1}, alpha: 0, want: nil},
+		{name: "plain", values: []float64{2, 4, 8}, alpha: 0.5, want: []float64{2, 3, 5.5}},
+		{name: "NaN holds", values: []float64{nan, 2, nan, 4}, alpha: 0.5, want: []float64{nan, 2, 2, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ExponentialMovingAverage(test.values, test.alpha); !equalFloats(got, test.want) {
+				t.Errorf("ExponentialMovingAverage() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+// equalFloats compares element-wise, treating NaNs as equal
+func equalFloats(a, b []float64) bool {
+	return slices.EqualFunc(a, b, func(x, y float64) bool {
+		return x == y || (math.IsNaN(x) && math.IsNaN(y))
+	})
+}
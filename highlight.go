@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// TextRange is a byte range [Start, End) within a string
+type TextRange struct {
+	Start int
+	End   int
+}
+
+// MatchRanges returns every non-overlapping occurrence of query in text,
+// ignoring ASCII case, for highlighting search hits
+func MatchRanges(text, query string) []TextRange {
+	if query == "" {
+		return nil
+	}
+
+	lowerText := strings.ToLower(text)
+	lowerQuery := strings.ToLower(query)
+	// ToLower may change byte lengths outside ASCII; fall back to exact matching
+	if len(lowerText) != len(text) || len(lowerQuery) != len(query) {
+		lowerText, lowerQuery = text, query
+	}
+
+	var ranges []TextRange
+	for offset := 0; offset < len(lowerText); {
+		index := strings.Index(lowerText[offset:], lowerQuery)
+		if index < 0 {
+			break
+		}
+		start := offset + index
+		ranges = append(ranges, TextRange{Start: start, End: start + len(query)})
+		offset = start + len(query)
+	}
+	return ranges
+}
+
+// drawTextHighlights fills the background of ranges in text laid out
+// unwrapped from pos, one line per newline
+func drawTextHighlights(drawList *imgui.DrawList, pos imgui.Vec2, text string, ranges []TextRange, color uint32) {
+	lineHeight := imgui.TextLineHeight()
+
+	lineStart := 0
+	for line := 0; lineStart <= len(text); line++ {
+		lineEnd := strings.IndexByte(text[lineStart:], '\n')
+		if lineEnd < 0 {
+			lineEnd = len(text)
+		} else {
+			lineEnd += lineStart
+		}
+
+		y := pos.Y + float32(line)*lineHeight
+		for _, r := range ranges {
+			start, end := max(r.Start, lineStart), min(r.End, lineEnd)
+			if start >= end {
+				continue
+			}
+			x0 := pos.X + imgui.CalcTextSize(text[lineStart:start]).X
+			x1 := pos.X + imgui.CalcTextSize(text[lineStart:end]).X
+			drawList.AddRectFilled(imgui.Vec2{X: x0, Y: y}, imgui.Vec2{X: x1, Y: y + lineHeight}, color)
+		}
+
+		lineStart = lineEnd + 1
+	}
+}
+
+// HighlightedTextWidget renders text with background highlights over the
+// given ranges, e.g. to show search matches
+type HighlightedTextWidget struct {
+	text   string
+	ranges []TextRange
+	color  *imgui.Vec4
+}
+
+// HighlightedText creates a text widget with highlighted ranges
+func HighlightedText(text string) *HighlightedTextWidget {
+	return &HighlightedTextWidget{text: text}
+}
+
+// Highlight sets the byte ranges to highlight (builder pattern)
+func (h *HighlightedTextWidget) Highlight(ranges ...TextRange) *HighlightedTextWidget {
+	h.ranges = ranges
+	return h
+}
+
+// Matches highlights every occurrence of query, ignoring case (builder pattern)
+func (h *HighlightedTextWidget) Matches(query string) *HighlightedTextWidget {
+	h.ranges = MatchRanges(h.text, query)
+	return h
+}
+
+// Color sets the highlight color; defaults to the theme's text selection color (builder pattern)
+func (h *HighlightedTextWidget) Color(color imgui.Vec4) *HighlightedTextWidget {
+	h.color = &color
+	return h
+}
+
+func (h *HighlightedTextWidget) Build() {
+	defer beginDefaults(KindLabel).end()
+
+	if len(h.ranges) > 0 {
+		color := imgui.ColorU32Col(imgui.ColTextSelectedBg)
+		if h.color != nil {
+			color = imgui.ColorU32Vec4(*h.color)
+		}
+		drawTextHighlights(imgui.WindowDrawList(), imgui.CursorScreenPos(), h.text, h.ranges, color)
+	}
+
+	imgui.TextUnformatted(h.text)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// idleHandler fires once after a period without input
+type idleHandler struct {
+	after    time.Duration
+	callback func()
+	fired    bool
+}
+
+// idleTracker watches input activity between frames
+type idleTracker struct {
+	lastActivity time.Time
+	handlers     []*idleHandler
+	onActive     []func()
+}
+
+// OnIdle registers a callback fired once the user has not touched mouse or
+// keyboard for the given duration. It fires again after the next idle period.
+func (w *MasterWindow) OnIdle(after time.Duration, callback func()) *MasterWindow {
+	tracker := w.idleTracker()
+	tracker.handlers = append(tracker.handlers, &idleHandler{after: after, callback: callback})
+	return w
+}
+
+// OnActive registers a callback fired when input resumes after an idle period
+func (w *MasterWindow) OnActive(callback func()) *MasterWindow {
+	tracker := w.idleTracker()
+	tracker.onActive = append(tracker.onActive, callback)
+	return w
+}
+
+// IdleDuration returns how long it has been since the last input
+func (w *MasterWindow) IdleDuration() time.Duration {
+	return time.Since(w.idleTracker().lastActivity)
+}
+
+// idleTracker returns the window's tracker, installing it on first use
+func (w *MasterWindow) idleTracker() *idleTracker {
+	if w.idle == nil {
+		w.idle = &idleTracker{lastActivity: time.Now()}
+		w.BeforeFrame(w.idle.update)
+	}
+	return w.idle
+}
+
+// update checks for input this frame and fires the due callbacks
+func (t *idleTracker) update() {
+	now := time.Now()
+
+	if hasInputActivity() {
+		wasIdle := false
+		for _, handler := range t.handlers {
+			if handler.fired {
+				wasIdle = true
+				handler.fired = false
+			}
+		}
+
+		t.lastActivity = now
+		if wasIdle {
+			for _, callback := range t.onActive {
+				callback()
+			}
+		}
+		return
+	}
+
+	idleFor := now.Sub(t.lastActivity)
+	for _, handler := range t.handlers {
+		if !handler.fired && idleFor >= handler.after {
+			handler.fired = true
+			handler.callback()
+		}
+	}
+}
+
+// hasInputActivity reports whether the mouse or keyboard was used this frame
+func hasInputActivity() bool {
+	io := imgui.CurrentIO()
+
+	delta := io.MouseDelta()
+	if delta.X != 0 || delta.Y != 0 || io.MouseWheel() != 0 || io.MouseWheelH() != 0 {
+		return true
+	}
+	if imgui.IsAnyMouseDown() {
+		return true
+	}
+
+	for key := imgui.KeyNamedKeyBEGIN; key < imgui.KeyNamedKeyEND; key++ {
+		if imgui.IsKeyDown(key) {
+			return true
+		}
+	}
+	return false
+}
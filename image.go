@@ -0,0 +1,361 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+
+	"github.com/AllenDang/cimgui-go/backend"
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// imageFileBudget is how much texture memory images loaded by path may use
+const imageFileBudget = 256 << 20
+
+// imageFiles caches the textures of images loaded by path, created on first use
+var imageFiles *TextureCache
+
+// fileTextures returns the cache of textures loaded by path
+func fileTextures() *TextureCache {
+	if imageFiles == nil {
+		imageFiles = NewTextureCache(imageFileBudget)
+	}
+	return imageFiles
+}
+
+// EnqueueTextureLoad decodes the image file at path in the background and
+// calls onLoaded on the UI thread once its texture is uploaded or loading
+// failed. It shares its cache with Image, which draws a placeholder until
+// the texture is ready; draw the texture with Image(path) so it stays
+// cached while in use.
+func EnqueueTextureLoad(path string, onLoaded func(texture *backend.Texture, err error)) {
+	fileTextures().Load(path, func() (image.Image, error) {
+		return loadImageFile(path)
+	}, onLoaded)
+}
+
+// ImageWidget shows an image file. The file is decoded in the background
+// the first time it is shown and kept on the GPU while it is in use.
+type ImageWidget struct {
+	path   string
+	width  float32
+	height float32
+}
+
+// Image creates a widget showing the PNG or JPEG file at path
+func Image(path string) *ImageWidget {
+	return &ImageWidget{path: path}
+}
+
+// Size sets the image size; zero keeps the image's own size on that axis (builder pattern)
+func (i *ImageWidget) Size(width, height float32) *ImageWidget {
+	i.width = width
+	i.height = height
+	return i
+}
+
+// loadImageFile decodes an image file
+func loadImageFile(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	return img, err
+}
+
+func (i *ImageWidget) Build() {
+	texture := fileTextures().Get(i.path, func() (image.Image, error) {
+		return loadImageFile(i.path)
+	})
+
+	size := imgui.Vec2{X: i.width, Y: i.height}
+	if texture == nil {
+		// Hold the space while loading so the layout does not jump
+		if size.X <= 0 || size.Y <= 0 {
+			side := imgui.FrameHeight()
+			size = imgui.Vec2{X: max(size.X, side), Y: max(size.Y, side)}
+		}
+		pos := imgui.CursorScreenPos()
+		imgui.Dummy(size)
+		imgui.WindowDrawList().AddRectFilled(pos, pos.Add(size), imgui.ColorU32Col(imgui.ColFrameBg))
+		if err := imageFiles.Err(i.path); err != nil && imgui.IsItemHovered() {
+			imgui.SetTooltip(err.Error())
+		}
+		return
+	}
+
+	if size.X <= 0 {
+		size.X = float32(texture.Width)
+	}
+	if size.Y <= 0 {
+		size.Y = float32(texture.Height)
+	}
+	imgui.Image(texture.ID, size)
+}
+
+// ImageButtonWidget is a clickable image that tints itself while hovered
+// and pressed
+type ImageButtonWidget struct {
+	id      string
+	texture *backend.Texture
+	width   float32
+	height  float32
+	frame   bool
+	tint    [3]imgui.Vec4 // normal, hovered, pressed
+	onClick func()
+}
+
+// ImageButton creates a button showing texture at its natural size
+func ImageButton(id string, texture *backend.Texture) *ImageButtonWidget {
+	return &ImageButtonWidget{
+		id:      id,
+		texture: texture,
+		frame:   true,
+		tint: [3]imgui.Vec4{
+			{X: 0.85, Y: 0.85, Z: 0.85, W: 1},
+			{X: 1, Y: 1, Z: 1, W: 1},
+			{X: 0.7, Y: 0.7, Z: 0.7, W: 1},
+		},
+	}
+}
+
+// Size sets the image size; zero keeps the texture size (builder pattern)
+func (i *ImageButtonWidget) Size(width, height float32) *ImageButtonWidget {
+	i.width = width
+	i.height = height
+	return i
+}
+
+// Frame toggles the button background behind the image (builder pattern)
+func (i *ImageButtonWidget) Frame(frame bool) *ImageButtonWidget {
+	i.frame = frame
+	return i
+}
+
+// Tint sets the colors multiplied with the image in each state (builder pattern)
+func (i *ImageButtonWidget) Tint(normal, hovered, pressed imgui.Vec4) *ImageButtonWidget {
+	i.tint = [3]imgui.Vec4{normal, hovered, pressed}
+	return i
+}
+
+// OnClick sets the click callback (builder pattern)
+func (i *ImageButtonWidget) OnClick(onClick func()) *ImageButtonWidget {
+	i.onClick = onClick
+	return i
+}
+
+// size returns the image size, falling back to the texture's own
+func (i *ImageButtonWidget) size() imgui.Vec2 {
+	size := imgui.Vec2{X: i.width, Y: i.height}
+	if i.texture != nil {
+		if size.X <= 0 {
+			size.X = float32(i.texture.Width)
+		}
+		if size.Y <= 0 {
+			size.Y = float32(i.texture.Height)
+		}
+	}
+	return size
+}
+
+func (i *ImageButtonWidget) Build() {
+	defaults := beginDefaults(KindButton)
+	defer defaults.end()
+
+	padding := imgui.CurrentStyle().FramePadding()
+	imageSize := i.size()
+	pos := imgui.CursorScreenPos()
+
+	clicked := imgui.InvisibleButton(i.id, imageSize.Add(padding.Mul(2)))
+
+	state, color := 0, imgui.ColButton
+	if imgui.IsItemActive() {
+		state, color = 2, imgui.ColButtonActive
+	} else if imgui.IsItemHovered() {
+		state, color = 1, imgui.ColButtonHovered
+	}
+
+	drawList := imgui.WindowDrawList()
+	if i.frame {
+		drawList.AddRectFilledV(imgui.ItemRectMin(), imgui.ItemRectMax(), imgui.ColorU32Col(color), imgui.CurrentStyle().FrameRounding(), 0)
+	}
+	if i.texture == nil {
+		ReportDiagnostic(fmt.Sprintf("image button %q has no texture", i.id))
+	} else {
+		imageMin := pos.Add(padding)
+		drawList.AddImageV(i.texture.ID, imageMin, imageMin.Add(imageSize),
+			imgui.Vec2{}, imgui.Vec2{X: 1, Y: 1}, imgui.ColorU32Vec4(i.tint[state]))
+	}
+
+	if clicked && i.onClick != nil {
+		i.onClick()
+	}
+}
+
+// AvatarWidget shows a user picture, optionally clipped to a circle and
+// with a presence dot
+type AvatarWidget struct {
+	texture  *backend.Texture
+	size     float32
+	round    bool
+	initials string
+	status   *imgui.Vec4
+}
+
+// Avatar creates an avatar from texture; with a nil texture the initials
+// are shown instead
+func Avatar(texture *backend.Texture) *AvatarWidget {
+	return &AvatarWidget{texture: texture, size: 32}
+}
+
+// Size sets the avatar's width and height (builder pattern)
+func (a *AvatarWidget) Size(size float32) *AvatarWidget {
+	a.size = size
+	return a
+}
+
+// Round clips the avatar to a circle (builder pattern)
+func (a *AvatarWidget) Round() *AvatarWidget {
+	a.round = true
+	return a
+}
+
+// Initials sets the text shown while there is no texture (builder pattern)
+func (a *AvatarWidget) Initials(initials string) *AvatarWidget {
+	a.initials = initials
+	return a
+}
+
+// Status shows a dot of the given color on the bottom-right edge, e.g. for presence (builder pattern)
+func (a *AvatarWidget) Status(color imgui.Vec4) *AvatarWidget {
+	a.status = &color
+	return a
+}
+
+func (a *AvatarWidget) Build() {
+	pos := imgui.CursorScreenPos()
+	size := imgui.Vec2{X: a.size, Y: a.size}
+	imgui.Dummy(size)
+
+	rounding := imgui.CurrentStyle().FrameRounding()
+	if a.round {
+		rounding = a.size / 2
+	}
+
+	drawList := imgui.WindowDrawList()
+	if a.texture != nil {
+		drawList.AddImageRoundedV(a.texture.ID, pos, pos.Add(size), imgui.Vec2{}, imgui.Vec2{X: 1, Y: 1},
+			imgui.ColorU32Vec4(imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1}), rounding, imgui.DrawFlagsRoundCornersAll)
+	} else {
+		drawList.AddRectFilledV(pos, pos.Add(size), imgui.ColorU32Col(imgui.ColFrameBg), rounding, imgui.DrawFlagsRoundCornersAll)
+		if a.initials != "" {
+			textSize := imgui.CalcTextSize(a.initials)
+			drawList.AddTextVec2(pos.Add(size.Sub(textSize).Mul(0.5)), imgui.ColorU32Col(imgui.ColText), a.initials)
+		}
+	}
+
+	if a.status != nil {
+		// The dot sits on the edge, ringed with the window background to
+		// separate it from the picture
+		radius := max(a.size/8, 3)
+		center := pos.Add(size).Sub(imgui.Vec2{X: radius, Y: radius})
+		if a.round {
+			center = pos.Add(size.Mul(0.5 + 0.5*0.7071)).Sub(imgui.Vec2{X: radius / 2, Y: radius / 2})
+		}
+		drawList.AddCircleFilled(center, radius+1.5, imgui.ColorU32Col(imgui.ColWindowBg))
+		drawList.AddCircleFilled(center, radius, imgui.ColorU32Vec4(*a.status))
+	}
+}
+
+// rgbaImageState holds the texture uploaded from an in-memory image
+type rgbaImageState struct {
+	texture *backend.Texture
+	source  image.Image
+}
+
+func (s *rgbaImageState) Dispose() {
+	if s.texture != nil {
+		s.texture.Release()
+		s.texture = nil
+	}
+}
+
+// ImageWithRGBAWidget shows an in-memory image, such as a generated chart
+// or a camera frame
+type ImageWithRGBAWidget struct {
+	id      string
+	img     image.Image
+	width   float32
+	height  float32
+	refresh bool
+}
+
+// ImageWithRGBA creates a widget showing img. The image is uploaded when it
+// is first shown and again whenever a different image is passed; use
+// Update after drawing into the same image.
+func ImageWithRGBA(img image.Image) *ImageWithRGBAWidget {
+	return &ImageWithRGBAWidget{id: "##image_rgba", img: img}
+}
+
+// ID sets the widget ID, which owns the texture; give sibling images
+// distinct IDs (builder pattern)
+func (i *ImageWithRGBAWidget) ID(id string) *ImageWithRGBAWidget {
+	i.id = fmt.Sprintf("##image_rgba_%s", id)
+	return i
+}
+
+// Size sets the image size; zero keeps the image's own size on that axis (builder pattern)
+func (i *ImageWithRGBAWidget) Size(width, height float32) *ImageWithRGBAWidget {
+	i.width = width
+	i.height = height
+	return i
+}
+
+// Update replaces the image and uploads it again on the next Build, even
+// if it is the same image with new pixels (builder pattern)
+func (i *ImageWithRGBAWidget) Update(img image.Image) *ImageWithRGBAWidget {
+	i.img = img
+	i.refresh = true
+	return i
+}
+
+func (i *ImageWithRGBAWidget) getState() *rgbaImageState {
+	if existingState, exists := GlobalContext.stateMap[i.id]; exists {
+		if state, ok := existingState.(*rgbaImageState); ok {
+			return state
+		}
+	}
+
+	newState := &rgbaImageState{}
+	GlobalContext.stateMap[i.id] = newState
+	return newState
+}
+
+func (i *ImageWithRGBAWidget) Build() {
+	if i.img == nil {
+		ReportDiagnostic(fmt.Sprintf("image %q has no image", i.id))
+		return
+	}
+
+	state := i.getState()
+	if state.texture == nil || state.source != i.img || i.refresh {
+		state.Dispose()
+		state.texture = backend.NewTextureFromRgba(imageToRGBA(i.img))
+		state.source = i.img
+		i.refresh = false
+	}
+
+	size := imgui.Vec2{X: i.width, Y: i.height}
+	if size.X <= 0 {
+		size.X = float32(state.texture.Width)
+	}
+	if size.Y <= 0 {
+		size.Y = float32(state.texture.Height)
+	}
+	imgui.Image(state.texture.ID, size)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// inputHistoryState holds an input's previous submissions, oldest first
+type inputHistoryState struct {
+	entries []string
+	index   int    // entries recalled back from the newest, -1 while editing a new line
+	draft   string // the unsubmitted text, restored when navigating past the newest entry
+}
+
+func (s *inputHistoryState) Dispose() {
+	s.entries = nil
+}
+
+func (s *inputHistoryState) Snapshot() any {
+	return map[string]any{"entries": append([]string(nil), s.entries...)}
+}
+
+// add records a submission, keeping at most limit entries
+func (s *inputHistoryState) add(text string, limit int) {
+	s.index = -1
+	s.draft = ""
+	if text == "" || (len(s.entries) > 0 && s.entries[len(s.entries)-1] == text) {
+		return
+	}
+
+	s.entries = append(s.entries, text)
+	if len(s.entries) > limit {
+		s.entries = s.entries[len(s.entries)-limit:]
+	}
+}
+
+// recall handles an up/down history event; current is the field's text
+// before this frame's edits
+func (s *inputHistoryState) recall(data imgui.InputTextCallbackData, current string) {
+	if len(s.entries) == 0 {
+		return
+	}
+
+	switch data.EventKey() {
+	case imgui.KeyUpArrow:
+		if s.index+1 >= len(s.entries) {
+			return
+		}
+		if s.index < 0 {
+			s.draft = current
+		}
+		s.index++
+	case imgui.KeyDownArrow:
+		if s.index < 0 {
+			return
+		}
+		s.index--
+	default:
+		return
+	}
+
+	text := s.draft
+	if s.index >= 0 {
+		text = s.entries[len(s.entries)-1-s.index]
+	}
+	data.DeleteChars(0, data.BufTextLen())
+	data.InsertChars(0, text)
+}
+
+// History keeps the last n submitted lines and recalls them with the up
+// and down arrows (builder pattern)
+func (i *InputTextWidget) History(n int) *InputTextWidget {
+	i.history = n
+	return i
+}
+
+// HistoryEntries returns the submitted lines, oldest first
+func (i *InputTextWidget) HistoryEntries() []string {
+	return append([]string(nil), i.getHistory().entries...)
+}
+
+func (i *InputTextWidget) getHistory() *inputHistoryState {
+	id := fmt.Sprintf("##inputhistory_%d", imgui.IDStr(i.id))
+	if existingState, exists := GlobalContext.stateMap[id]; exists {
+		if state, ok := existingState.(*inputHistoryState); ok {
+			return state
+		}
+	}
+
+	newState := &inputHistoryState{index: -1}
+	GlobalContext.stateMap[id] = newState
+	return newState
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// keyboardAudit is set while keyboard reachability is being checked
+var keyboardAudit bool
+
+// SetKeyboardAudit toggles a development mode that reports, as
+// diagnostics, interactions the keyboard cannot perform: click handlers on
+// items that cannot take focus and mouse-only gestures without a key
+// binding. Offending items are outlined on screen.
+func SetKeyboardAudit(enabled bool) {
+	keyboardAudit = enabled
+}
+
+// IsKeyboardAudit reports whether the keyboard audit is on
+func IsKeyboardAudit() bool {
+	return keyboardAudit
+}
+
+// checkKeyboardNavigation reports when keyboard navigation is off for the
+// whole app; runs before each frame
+func checkKeyboardNavigation() {
+	if keyboardAudit && imgui.CurrentIO().ConfigFlags()&imgui.ConfigFlagsNavEnableKeyboard == 0 {
+		ReportDiagnostic("keyboard navigation is disabled (ConfigFlagsNavEnableKeyboard is not set)")
+	}
+}
+
+// auditKeyboard checks the widget just built; called by buildWidget
+func auditKeyboard(widget Widget) {
+	event, ok := widget.(*EventWidget)
+	if !ok {
+		return
+	}
+
+	// Items without an id, such as labels, can be neither focused nor activated
+	clickable := event.onClick != nil || event.onDoubleClick != nil
+	if clickable && imgui.ItemID() == 0 {
+		reportKeyboardIssue("click handler on an item that cannot take keyboard focus; use a Button or Selectable")
+		return
+	}
+
+	mouseOnly := event.onDoubleClick != nil || event.onRightClick != nil || event.onRightClickRelease != nil ||
+		event.onDragStart != nil || event.onDragEnd != nil
+	if mouseOnly && len(event.keyHandlers) == 0 && event.onKeyPress == nil {
+		reportKeyboardIssue("double-click, right-click or drag handler without a key binding")
+	}
+}
+
+// reportKeyboardIssue reports a problem with the last item and outlines it
+func reportKeyboardIssue(message string) {
+	ReportDiagnostic(message)
+	imgui.ForegroundDrawListViewportPtr().AddRectV(imgui.ItemRectMin(), imgui.ItemRectMax(),
+		imgui.ColorU32Vec4(imgui.Vec4{X: 1, Y: 0.2, Z: 0.6, W: 1}), 0, 0, 2)
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// KeyChord is a key together with the modifiers held while pressing it
+type KeyChord struct {
+	Key   imgui.Key
+	Ctrl  bool
+	Shift bool
+	Alt   bool
+	Super bool
+}
+
+// String formats the chord like "Ctrl+Shift+S"
+func (c KeyChord) String() string {
+	if c.Key == imgui.KeyNone {
+		return ""
+	}
+
+	parts := make([]string, 0, 5)
+	if c.Ctrl {
+		parts = append(parts, "Ctrl")
+	}
+	if c.Shift {
+		parts = append(parts, "Shift")
+	}
+	if c.Alt {
+		parts = append(parts, "Alt")
+	}
+	if c.Super {
+		parts = append(parts, "Super")
+	}
+	parts = append(parts, imgui.KeyName(c.Key))
+	return strings.Join(parts, "+")
+}
+
+// isModifierKey reports whether key is one of the modifier keys themselves
+func isModifierKey(key imgui.Key) bool {
+	switch key {
+	case imgui.KeyLeftCtrl, imgui.KeyRightCtrl,
+		imgui.KeyLeftShift, imgui.KeyRightShift,
+		imgui.KeyLeftAlt, imgui.KeyRightAlt,
+		imgui.KeyLeftSuper, imgui.KeyRightSuper:
+		return true
+	}
+	return false
+}
+
+// isPseudoKey reports whether key is one of the named keys ImGui uses for
+// modifier state and mouse input rather than a key on the keyboard
+func isPseudoKey(key imgui.Key) bool {
+	switch key {
+	case imgui.KeyReservedForModCtrl, imgui.KeyReservedForModShift,
+		imgui.KeyReservedForModAlt, imgui.KeyReservedForModSuper,
+		imgui.KeyMouseLeft, imgui.KeyMouseRight, imgui.KeyMouseMiddle,
+		imgui.KeyMouseX1, imgui.KeyMouseX2,
+		imgui.KeyMouseWheelX, imgui.KeyMouseWheelY:
+		return true
+	}
+	return false
+}
+
+// keyCaptureState tracks whether the button is waiting for a key
+type keyCaptureState struct {
+	capturing bool
+}
+
+func (s *keyCaptureState) Dispose() {
+	// Nothing to clean up
+}
+
+func (s *keyCaptureState) Snapshot() any {
+	return map[string]any{"capturing": s.capturing}
+}
+
+// KeyCaptureButtonWidget records the next key chord pressed after it is clicked
+type KeyCaptureButtonWidget struct {
+	id        string
+	chord     *KeyChord
+	width     float32
+	onCapture func(chord KeyChord)
+}
+
+// KeyCaptureButton creates a button for rebinding keys
+func KeyCaptureButton(id string) *KeyCaptureButtonWidget {
+	return &KeyCaptureButtonWidget{id: fmt.Sprintf("##keycapture_%s", id)}
+}
+
+// Chord binds the chord shown on the button and updated on capture (builder pattern)
+func (k *KeyCaptureButtonWidget) Chord(chord *KeyChord) *KeyCaptureButtonWidget {
+	k.chord = chord
+	return k
+}
+
+// Width sets the button width (builder pattern)
+func (k *KeyCaptureButtonWidget) Width(width float32) *KeyCaptureButtonWidget {
+	k.width = width
+	return k
+}
+
+// OnCapture sets the callback receiving the captured chord (builder pattern)
+func (k *KeyCaptureButtonWidget) OnCapture(onCapture func(chord KeyChord)) *KeyCaptureButtonWidget {
+	k.onCapture = onCapture
+	return k
+}
+
+func (k *KeyCaptureButtonWidget) getState() *keyCaptureState {
+	if existingState, exists := GlobalContext.stateMap[k.id]; exists {
+		if state, ok := existingState.(*keyCaptureState); ok {
+			return state
+		}
+	}
+
+	newState := &keyCaptureState{}
+	GlobalContext.stateMap[k.id] = newState
+	return newState
+}
+
+func (k *KeyCaptureButtonWidget) Build() {
+	state := k.getState()
+
+	label := "(none)"
+	if state.capturing {
+		label = "Press a key…"
+	} else if k.chord != nil && k.chord.Key != imgui.KeyNone {
+		label = k.chord.String()
+	}
+
+	if imgui.ButtonV(label+k.id, imgui.Vec2{X: k.width}) {
+		state.capturing = !state.capturing
+		return
+	}
+
+	if !state.capturing {
+		return
+	}
+
+	// Clicking anywhere else or pressing Escape cancels the capture
+	if imgui.IsKeyPressedBoolV(imgui.KeyEscape, false) ||
+		(imgui.IsMouseClickedBool(imgui.MouseButtonLeft) && !imgui.IsItemHovered()) {
+		state.capturing = false
+		return
+	}
+
+	io := imgui.CurrentIO()
+	for key := imgui.KeyNamedKeyBEGIN; key < imgui.KeyNamedKeyEND; key++ {
+		if key == imgui.KeyEscape || isModifierKey(key) || isPseudoKey(key) || !imgui.IsKeyPressedBoolV(key, false) {
+			continue
+		}
+
+		chord := KeyChord{
+			Key:   key,
+			Ctrl:  io.KeyCtrl(),
+			Shift: io.KeyShift(),
+			Alt:   io.KeyAlt(),
+			Super: io.KeySuper(),
+		}
+		state.capturing = false
+
+		if k.chord != nil {
+			*k.chord = chord
+		}
+		if k.onCapture != nil {
+			k.onCapture(chord)
+		}
+		return
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// itemDecorator is implemented by widgets that act on the previously built
+// item (tooltips, events, same-line placement, ...) instead of drawing one
+// of their own
+type itemDecorator interface {
+	decoratesPreviousItem()
+}
+
+func (t *TooltipWidget) decoratesPreviousItem()     {}
+func (e *EventWidget) decoratesPreviousItem()       {}
+func (h *HotkeyWidget) decoratesPreviousItem()      {}
+func (t *TourTargetWidget) decoratesPreviousItem()  {}
+func (s *SameLineWidget) decoratesPreviousItem()    {}
+func (b *BadgeWidget) decoratesPreviousItem()       {}
+func (c *ContextMenuWidget) decoratesPreviousItem() {}
+
+// layoutRect is a widget's bounds recorded for the debug overlay
+type layoutRect struct {
+	min, max imgui.Vec2
+	depth    int
+	name     string
+}
+
+// Layout debug overlay state
+var (
+	layoutDebug       bool
+	layoutDebugDepth  int
+	layoutDebugFrames []layoutRect
+)
+
+// SetLayoutDebug toggles the overlay drawing every widget's bounds, padding
+// and spacing
+func SetLayoutDebug(enabled bool) {
+	layoutDebug = enabled
+}
+
+// IsLayoutDebug reports whether the layout debug overlay is on
+func IsLayoutDebug() bool {
+	return layoutDebug
+}
+
+// buildWidget builds a child widget; all containers go through it so the
+// framework can observe every widget built in a frame
+func buildWidget(widget Widget) {
+	if widget == nil {
+		return
+	}
+
+	buildingWidgets = append(buildingWidgets, widget)
+	defer func() { buildingWidgets = buildingWidgets[:len(buildingWidgets)-1] }()
+	if styleDebug {
+		defer beginStyleCheck()()
+	}
+	if profiling {
+		defer beginProfile(widget)()
+	}
+	if keyboardAudit {
+		defer auditKeyboard(widget)
+	}
+
+	if _, isDecorator := widget.(itemDecorator); isDecorator || !layoutDebug {
+		widget.Build()
+		return
+	}
+
+	// Grouping makes the item rect cover everything the widget drew
+	layoutDebugDepth++
+	imgui.BeginGroup()
+	widget.Build()
+	imgui.EndGroup()
+	layoutDebugDepth--
+
+	layoutDebugFrames = append(layoutDebugFrames, layoutRect{
+		min:   imgui.ItemRectMin(),
+		max:   imgui.ItemRectMax(),
+		depth: layoutDebugDepth,
+		name:  fmt.Sprintf("%T", widget),
+	})
+}
+
+// layoutDebugColors cycles by nesting depth
+var layoutDebugColors = []imgui.Vec4{
+	{X: 1.0, Y: 0.3, Z: 0.3, W: 0.9},
+	{X: 0.3, Y: 1.0, Z: 0.3, W: 0.9},
+	{X: 0.3, Y: 0.6, Z: 1.0, W: 0.9},
+	{X: 1.0, Y: 0.8, Z: 0.2, W: 0.9},
+	{X: 0.9, Y: 0.4, Z: 1.0, W: 0.9},
+}
+
+// drawLayoutDebug draws the rectangles recorded this frame; runs after the
+// user's loop so every widget has been built
+func drawLayoutDebug() {
+	if !layoutDebug {
+		layoutDebugFrames = layoutDebugFrames[:0]
+		return
+	}
+
+	drawList := imgui.ForegroundDrawListViewportPtr()
+	style := imgui.CurrentStyle()
+	padding := style.FramePadding()
+	spacing := style.ItemSpacing()
+	mouse := imgui.MousePos()
+
+	var hovered *layoutRect
+	for i := range layoutDebugFrames {
+		rect := &layoutDebugFrames[i]
+		color := layoutDebugColors[rect.depth%len(layoutDebugColors)]
+
+		// Item spacing below the widget
+		drawList.AddRectFilled(imgui.Vec2{X: rect.min.X, Y: rect.max.Y}, imgui.Vec2{X: rect.max.X, Y: rect.max.Y + spacing.Y},
+			imgui.ColorU32Vec4(WithAlpha(color, 0.15)))
+
+		// Bounds and the frame padding inset
+		drawList.AddRect(rect.min, rect.max, imgui.ColorU32Vec4(color))
+		if rect.max.X-rect.min.X > 2*padding.X && rect.max.Y-rect.min.Y > 2*padding.Y {
+			drawList.AddRect(rect.min.Add(padding), rect.max.Sub(padding), imgui.ColorU32Vec4(WithAlpha(color, 0.35)))
+		}
+
+		// The innermost rectangle under the mouse wins
+		if mouse.X >= rect.min.X && mouse.X <= rect.max.X && mouse.Y >= rect.min.Y && mouse.Y <= rect.max.Y {
+			if hovered == nil || rect.depth >= hovered.depth {
+				hovered = rect
+			}
+		}
+	}
+
+	if hovered != nil {
+		size := hovered.max.Sub(hovered.min)
+		label := fmt.Sprintf("%s  %.0fx%.0f @ (%.0f, %.0f)", hovered.name, size.X, size.Y, hovered.min.X, hovered.min.Y)
+		labelPos := imgui.Vec2{X: mouse.X + 16, Y: mouse.Y + 16}
+		drawList.AddRectFilled(labelPos.Sub(imgui.Vec2{X: 4, Y: 2}), labelPos.Add(imgui.CalcTextSize(label)).Add(imgui.Vec2{X: 4, Y: 2}),
+			imgui.ColorU32Vec4(imgui.Vec4{X: 0, Y: 0, Z: 0, W: 0.85}))
+		drawList.AddTextVec2(labelPos, imgui.ColorU32Vec4(imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1}), label)
+	}
+
+	layoutDebugFrames = layoutDebugFrames[:0]
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// BulletTextWidget is a single line of text with a bullet in front
+type BulletTextWidget struct {
+	text string
+}
+
+// BulletText creates a bulleted line of text
+func BulletText(text string) *BulletTextWidget {
+	return &BulletTextWidget{text: text}
+}
+
+func (b *BulletTextWidget) Build() {
+	defer beginDefaults(KindLabel).end()
+
+	imgui.BulletText(b.text)
+}
+
+// ListItemWidget is a bullet followed by arbitrary widgets on the same line
+type ListItemWidget struct {
+	widgets []Widget
+	level   int
+}
+
+// ListItem creates a bulleted item; the widgets are laid out after the bullet
+func ListItem(widgets ...Widget) *ListItemWidget {
+	return &ListItemWidget{widgets: widgets}
+}
+
+// Level sets the indentation level, 0 being flush with the surrounding content (builder pattern)
+func (l *ListItemWidget) Level(level int) *ListItemWidget {
+	l.level = level
+	return l
+}
+
+func (l *ListItemWidget) Build() {
+	indent := float32(l.level) * imgui.CurrentStyle().IndentSpacing()
+	if indent > 0 {
+		imgui.IndentV(indent)
+		defer imgui.UnindentV(indent)
+	}
+
+	imgui.AlignTextToFramePadding()
+	imgui.Bullet()
+	for _, widget := range l.widgets {
+		imgui.SameLine()
+		buildWidget(widget)
+	}
+}
+
+// ListWidget lays out items with bullets or numbers; a nested List becomes
+// an indented sub-list of the item before it
+type ListWidget struct {
+	items   []Widget
+	ordered bool
+	start   int
+}
+
+// List creates an unordered list. Strings can be passed with Label.
+func List(items ...Widget) *ListWidget {
+	return &ListWidget{items: items, start: 1}
+}
+
+// Ordered numbers the items instead of bulleting them (builder pattern)
+func (l *ListWidget) Ordered(ordered bool) *ListWidget {
+	l.ordered = ordered
+	return l
+}
+
+// Start sets the number of the first item in an ordered list (builder pattern)
+func (l *ListWidget) Start(start int) *ListWidget {
+	l.start = start
+	return l
+}
+
+func (l *ListWidget) Build() {
+	if len(l.items) == 0 {
+		return
+	}
+
+	// Numbers are right-aligned to the widest one so the items line up
+	markerWidth := float32(0)
+	if l.ordered {
+		markerWidth = imgui.CalcTextSize(fmt.Sprintf("%d.", l.start+len(l.items)-1)).X
+	}
+
+	number := l.start
+	for _, item := range l.items {
+		if nested, ok := item.(*ListWidget); ok {
+			imgui.Indent()
+			buildWidget(nested)
+			imgui.Unindent()
+			continue
+		}
+
+		imgui.AlignTextToFramePadding()
+		if l.ordered {
+			marker := fmt.Sprintf("%d.", number)
+			imgui.SetCursorPosX(imgui.CursorPosX() + markerWidth - imgui.CalcTextSize(marker).X)
+			imgui.Text(marker)
+			number++
+		} else {
+			imgui.Bullet()
+		}
+		imgui.SameLine()
+		buildWidget(item)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// listBoxState holds the selected item
+type listBoxState struct {
+	selected int
+}
+
+func (s *listBoxState) Dispose() {
+	// Nothing to clean up
+}
+
+func (s *listBoxState) Snapshot() any {
+	return map[string]any{"selected": s.selected}
+}
+
+// ListBoxWidget is a framed, scrolling list with a single selection
+type ListBoxWidget struct {
+	id            string
+	items         []string
+	width         float32
+	height        float32
+	onSelect      func(index int)
+	onDoubleClick func(index int)
+
+	// Rows built on demand instead of items
+	rowCount   int
+	rowBuilder func(i int) Widget
+}
+
+// ListBox creates a list box showing items
+func ListBox(id string, items []string) *ListBoxWidget {
+	return &ListBoxWidget{
+		id:    fmt.Sprintf("##listbox_%s", id),
+		items: items,
+	}
+}
+
+// Size sets the list size; zero width fills the available space and zero
+// height shows about seven items (builder pattern)
+func (l *ListBoxWidget) Size(width, height float32) *ListBoxWidget {
+	l.width = width
+	l.height = height
+	return l
+}
+
+// OnSelect sets the callback invoked with the index of a newly selected item (builder pattern)
+func (l *ListBoxWidget) OnSelect(onSelect func(index int)) *ListBoxWidget {
+	l.onSelect = onSelect
+	return l
+}
+
+// OnDoubleClick sets the callback invoked with the index of a double-clicked item (builder pattern)
+func (l *ListBoxWidget) OnDoubleClick(onDoubleClick func(index int)) *ListBoxWidget {
+	l.onDoubleClick = onDoubleClick
+	return l
+}
+
+// Virtualized replaces the items with rowCount widgets created by
+// rowBuilder, which is only called for the rows in view. The rows handle
+// their own clicks; selection and the callbacks apply to items only. Rows
+// must all have the same height (builder pattern)
+func (l *ListBoxWidget) Virtualized(rowCount int, rowBuilder func(i int) Widget) *ListBoxWidget {
+	l.rowCount = rowCount
+	l.rowBuilder = rowBuilder
+	return l
+}
+
+// Selected returns the selected index, or -1
+func (l *ListBoxWidget) Selected() int {
+	return l.getState().selected
+}
+
+// SetSelected selects an item without invoking OnSelect
+func (l *ListBoxWidget) SetSelected(index int) {
+	l.getState().selected = index
+}
+
+func (l *ListBoxWidget) getState() *listBoxState {
+	if existingState, exists := GlobalContext.stateMap[l.id]; exists {
+		if state, ok := existingState.(*listBoxState); ok {
+			return state
+		}
+	}
+
+	newState := &listBoxState{selected: -1}
+	GlobalContext.stateMap[l.id] = newState
+	return newState
+}
+
+func (l *ListBoxWidget) Build() {
+	state := l.getState()
+	if state.selected >= len(l.items) {
+		state.selected = -1
+	}
+
+	width := l.width
+	if width <= 0 {
+		width = -1
+	}
+	if !imgui.BeginListBoxV(l.id, imgui.Vec2{X: width, Y: l.height}) {
+		return
+	}
+
+	if l.rowBuilder != nil {
+		buildClipped(l.rowCount, func(i int) {
+			imgui.PushIDInt(int32(i))
+			buildWidget(l.rowBuilder(i))
+			imgui.PopID()
+		})
+	} else {
+		// Only the items in view are built, so long lists stay fast
+		buildClipped(len(l.items), func(i int) {
+			l.buildItem(state, i)
+		})
+	}
+	imgui.EndListBox()
+}
+
+// buildItem draws one selectable item
+func (l *ListBoxWidget) buildItem(state *listBoxState, i int) {
+	flags := imgui.SelectableFlagsAllowDoubleClick
+	if imgui.SelectableBoolV(fmt.Sprintf("%s##%d", l.items[i], i), state.selected == i, flags, imgui.Vec2{}) {
+		if state.selected != i {
+			state.selected = i
+			if l.onSelect != nil {
+				l.onSelect(i)
+			}
+		}
+		if imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) && l.onDoubleClick != nil {
+			l.onDoubleClick(i)
+		}
+	}
+	if state.selected == i {
+		imgui.SetItemDefaultFocus()
+	}
+}
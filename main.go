@@ -3,8 +3,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"os"
 	"runtime"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/AllenDang/cimgui-go/backend"
 	"github.com/AllenDang/cimgui-go/backend/glfwbackend"
@@ -41,10 +47,21 @@ var (
 
 // MasterWindow represents the main application window
 type MasterWindow struct {
-	backend backend.Backend[glfwbackend.GLFWWindowFlags]
-	title   string
-	width   int
-	height  int
+	backend    backend.Backend[glfwbackend.GLFWWindowFlags]
+	title      string
+	width      int
+	height     int
+	onActivate func(args []string)
+	splash     *SplashScreen
+	idle       *idleTracker
+	mouse      *mouseHandlers
+	fonts      *FontManager
+
+	relativeMouse bool
+
+	// Frame hooks, run in registration order
+	beforeFrame []func()
+	afterFrame  []func()
 }
 
 // Global status display instance
@@ -55,10 +72,31 @@ var currentThemeObject *Theme
 
 // LogStatus adds a message to the global status display
 func LogStatus(message string) {
+	logStatusLevel(StatusInfo, message)
+}
+
+// LogWarning adds a warning to the global status display
+func LogWarning(message string) {
+	logStatusLevel(StatusWarn, message)
+}
+
+// LogError adds an error to the global status display
+func LogError(message string) {
+	logStatusLevel(StatusError, message)
+}
+
+func logStatusLevel(level StatusLevel, message string) {
 	if globalStatus != nil {
-		globalStatus.AddMessage(message)
+		globalStatus.AddLevelMessage(level, message)
+	}
+	if crashSubmit != nil {
+		rememberLogLine(fmt.Sprintf("%s: %s", level, message))
+	}
+	if level == StatusInfo {
+		fmt.Printf("[STATUS] %s\n", message)
+	} else {
+		fmt.Printf("[STATUS] %s: %s\n", level, message)
 	}
-	fmt.Printf("[STATUS] %s\n", message)
 }
 
 // FIXED: Proper global theme application
@@ -77,18 +115,39 @@ type Layout []Widget
 
 func (l Layout) Build() {
 	for _, widget := range l {
-		if widget != nil {
-			widget.Build()
-		}
+		buildWidget(widget)
 	}
 }
 
 type EventWidget struct {
-	onHover       func()
-	onClick       func()
-	onDoubleClick func()
-	onRightClick  func()
-	onKeyPress    func(key int)
+	id                  string
+	onHover             func()
+	onClick             func()
+	onDoubleClick       func()
+	onRightClick        func()
+	onRightClickRelease func()
+	onMouseEnter        func()
+	onMouseLeave        func()
+	onFocus             func()
+	onBlur              func()
+	onDragStart         func(start imgui.Vec2)
+	onDragEnd           func(delta imgui.Vec2)
+	onKeyPress          func(key int)
+	keyHandlers         map[imgui.Key]func()
+	cursor              *MouseCursor
+}
+
+// eventState remembers the previous item's status to detect transitions
+type eventState struct {
+	hovered  bool
+	focused  bool
+	pressed  bool
+	dragging bool
+	dragFrom imgui.Vec2
+}
+
+func (s *eventState) Dispose() {
+	// Nothing to clean up
 }
 
 // Event creates an event handler widget
@@ -96,6 +155,13 @@ func Event() *EventWidget {
 	return &EventWidget{}
 }
 
+// ID names the handler's state, needed after items without an ID such as Text or Label (builder pattern)
+func (e *EventWidget) ID(id string) *EventWidget {
+	e.id = id
+	return e
+}
+
+// OnHover is called every frame while the previous item is hovered
 func (e *EventWidget) OnHover(onHover func()) *EventWidget {
 	e.onHover = onHover
 	return e
@@ -111,52 +177,182 @@ func (e *EventWidget) OnDoubleClick(onDoubleClick func()) *EventWidget {
 	return e
 }
 
+// OnRightClick is called every frame while the right button is held over the item
 func (e *EventWidget) OnRightClick(onRightClick func()) *EventWidget {
 	e.onRightClick = onRightClick
 	return e
 }
 
+// OnRightClickRelease is called once when the right button is released over the item
+func (e *EventWidget) OnRightClickRelease(onRightClickRelease func()) *EventWidget {
+	e.onRightClickRelease = onRightClickRelease
+	return e
+}
+
+// OnMouseEnter is called once when the mouse starts hovering the item
+func (e *EventWidget) OnMouseEnter(onMouseEnter func()) *EventWidget {
+	e.onMouseEnter = onMouseEnter
+	return e
+}
+
+// OnMouseLeave is called once when the mouse stops hovering the item
+func (e *EventWidget) OnMouseLeave(onMouseLeave func()) *EventWidget {
+	e.onMouseLeave = onMouseLeave
+	return e
+}
+
+// OnFocus is called once when the item gains keyboard focus
+func (e *EventWidget) OnFocus(onFocus func()) *EventWidget {
+	e.onFocus = onFocus
+	return e
+}
+
+// OnBlur is called once when the item loses keyboard focus
+func (e *EventWidget) OnBlur(onBlur func()) *EventWidget {
+	e.onBlur = onBlur
+	return e
+}
+
+// OnDragStart is called when a left-button drag starts on the item, with the press position
+func (e *EventWidget) OnDragStart(onDragStart func(start imgui.Vec2)) *EventWidget {
+	e.onDragStart = onDragStart
+	return e
+}
+
+// OnDragEnd is called when the drag is released, with the total mouse movement
+func (e *EventWidget) OnDragEnd(onDragEnd func(delta imgui.Vec2)) *EventWidget {
+	e.onDragEnd = onDragEnd
+	return e
+}
+
+// OnKeyPress is called with any key pressed while the item is focused
 func (e *EventWidget) OnKeyPress(onKeyPress func(key int)) *EventWidget {
 	e.onKeyPress = onKeyPress
 	return e
 }
 
+// OnKey subscribes to one key pressed while the item is focused
+func (e *EventWidget) OnKey(key imgui.Key, onKey func()) *EventWidget {
+	if e.keyHandlers == nil {
+		e.keyHandlers = make(map[imgui.Key]func())
+	}
+	e.keyHandlers[key] = onKey
+	return e
+}
+
+func (e *EventWidget) getState() *eventState {
+	id := e.id
+	if id == "" {
+		itemID := imgui.ItemID()
+		if itemID == 0 {
+			// Every unnamed item shares ID 0, so state kept under it would
+			// leak between handlers; transitions can't be tracked here
+			ReportDiagnostic("event handler on an item without an id; name it with ID")
+			return &eventState{}
+		}
+		id = fmt.Sprintf("##event_%d", itemID)
+	} else {
+		id = "##event_" + id
+	}
+
+	if existingState, exists := GlobalContext.stateMap[id]; exists {
+		if state, ok := existingState.(*eventState); ok {
+			return state
+		}
+	}
+
+	newState := &eventState{}
+	GlobalContext.stateMap[id] = newState
+	return newState
+}
+
 func (e *EventWidget) Build() {
+	applyHoverCursor(e.cursor)
+
+	state := e.getState()
+	hovered := imgui.IsItemHovered()
+	focused := imgui.IsItemFocused()
+
 	// Check if previous item was hovered
-	if imgui.IsItemHovered() && e.onHover != nil {
+	if hovered && e.onHover != nil {
 		e.onHover()
 	}
 
+	// Edge-triggered hover and focus transitions
+	if hovered != state.hovered {
+		if hovered && e.onMouseEnter != nil {
+			e.onMouseEnter()
+		} else if !hovered && e.onMouseLeave != nil {
+			e.onMouseLeave()
+		}
+		state.hovered = hovered
+	}
+
+	if focused != state.focused {
+		if focused && e.onFocus != nil {
+			e.onFocus()
+		} else if !focused && e.onBlur != nil {
+			e.onBlur()
+		}
+		state.focused = focused
+	}
+
 	// Check for mouse clicks on previous item
 	if imgui.IsItemClicked() && e.onClick != nil {
 		e.onClick()
 	}
 
-	if imgui.IsItemHovered() && imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) && e.onDoubleClick != nil {
+	if hovered && imgui.IsMouseDoubleClicked(imgui.MouseButtonLeft) && e.onDoubleClick != nil {
 		e.onDoubleClick()
 	}
 
-	if imgui.IsItemHovered() && imgui.IsMouseDown(imgui.MouseButtonRight) && e.onRightClick != nil {
+	if hovered && imgui.IsMouseDown(imgui.MouseButtonRight) && e.onRightClick != nil {
 		e.onRightClick()
 	}
 
-	// Check for key presses when item is focused
-	if imgui.IsItemFocused() && e.onKeyPress != nil {
-		// Check some common keys
-		if imgui.IsKeyPressedBoolV(imgui.KeyEnter, true) {
-			e.onKeyPress(int(imgui.KeyEnter))
+	if hovered && imgui.IsMouseReleased(imgui.MouseButtonRight) && e.onRightClickRelease != nil {
+		e.onRightClickRelease()
+	}
+
+	// Drags start with a press on the item and may end anywhere
+	if hovered && imgui.IsMouseClickedBool(imgui.MouseButtonLeft) {
+		state.pressed = true
+		state.dragFrom = imgui.MousePos()
+	}
+	if state.pressed && !state.dragging && imgui.IsMouseDragging(imgui.MouseButtonLeft) {
+		state.dragging = true
+		if e.onDragStart != nil {
+			e.onDragStart(state.dragFrom)
 		}
-		if imgui.IsKeyPressedBoolV(imgui.KeyEscape, true) {
-			e.onKeyPress(int(imgui.KeyEscape))
+	}
+	if state.pressed && !imgui.IsMouseDown(imgui.MouseButtonLeft) {
+		if state.dragging && e.onDragEnd != nil {
+			e.onDragEnd(imgui.MousePos().Sub(state.dragFrom))
 		}
-		if imgui.IsKeyPressedBoolV(imgui.KeySpace, true) {
-			e.onKeyPress(int(imgui.KeySpace))
+		state.pressed = false
+		state.dragging = false
+	}
+
+	// Check for key presses when item is focused
+	if focused && (e.onKeyPress != nil || len(e.keyHandlers) > 0) {
+		for key := imgui.KeyNamedKeyBEGIN; key < imgui.KeyNamedKeyEND; key++ {
+			if isPseudoKey(key) || !imgui.IsKeyPressedBoolV(key, true) {
+				continue
+			}
+			if handler, exists := e.keyHandlers[key]; exists {
+				handler()
+			}
+			if e.onKeyPress != nil {
+				e.onKeyPress(int(key))
+			}
 		}
 	}
 }
 
 type TooltipWidget struct {
-	text string
+	text    string
+	anchor  *Anchor
+	widgets []Widget
 }
 
 // Tooltip creates a tooltip widget
@@ -164,15 +360,44 @@ func Tooltip(text string) *TooltipWidget {
 	return &TooltipWidget{text: text}
 }
 
+// Layout shows widgets such as images and separators in the tooltip, below
+// the text if there is any (builder pattern)
+func (t *TooltipWidget) Layout(widgets ...Widget) *TooltipWidget {
+	t.widgets = widgets
+	return t
+}
+
 // Build shows the tooltip if previous item is hovered
 func (t *TooltipWidget) Build() {
-	if imgui.IsItemHovered() {
+	if !imgui.IsItemHovered() {
+		return
+	}
+	if t.anchor == nil && len(t.widgets) == 0 {
 		imgui.SetTooltip(t.text)
+		return
+	}
+
+	if t.anchor != nil {
+		t.anchor.place(imgui.ItemRectMin(), imgui.ItemRectMax(), anchoredSizes[t.text])
+	}
+	if imgui.BeginTooltip() {
+		if t.text != "" {
+			imgui.Text(t.text)
+		}
+		for _, widget := range t.widgets {
+			buildWidget(widget)
+		}
+		if t.anchor != nil {
+			anchoredSizes[t.text] = imgui.WindowSize()
+		}
+		imgui.EndTooltip()
 	}
 }
 
 type LabelWidget struct {
-	text string
+	text   string
+	cursor *MouseCursor
+	badge  *BadgeWidget
 }
 
 func Label(text string) *LabelWidget {
@@ -180,14 +405,25 @@ func Label(text string) *LabelWidget {
 }
 
 func (l *LabelWidget) Build() {
+	defer beginDefaults(KindLabel).end()
+
 	imgui.Text(l.text)
+	applyHoverCursor(l.cursor)
+	if l.badge != nil {
+		l.badge.Build()
+	}
 }
 
 type ButtonWidget struct {
-	text    string
-	onClick func()
-	width   float32
-	height  float32
+	text     string
+	onClick  func()
+	width    float32
+	height   float32
+	cursor   *MouseCursor
+	badge    *BadgeWidget
+	shortcut *KeyChord
+
+	disabledReason string
 }
 
 func Button(text string) *ButtonWidget {
@@ -201,11 +437,45 @@ func (b *ButtonWidget) OnClick(fn func()) *ButtonWidget {
 }
 
 func (b *ButtonWidget) Build() {
-	var clicked bool
+	defaults := beginDefaults(KindButton)
+	defer defaults.end()
+
+	var size imgui.Vec2
 	if b.width > 0 && b.height > 0 {
-		clicked = imgui.ButtonV(b.text, imgui.Vec2{X: b.width, Y: b.height})
+		size = imgui.Vec2{X: b.width, Y: b.height}
+	} else if defaults.width > 0 {
+		size = imgui.Vec2{X: defaults.width}
+	}
+
+	if b.disabledReason != "" {
+		imgui.BeginDisabled()
+	}
+
+	var clicked bool
+	if b.shortcut != nil {
+		// The label moves left to make room for the hint
+		hint := b.shortcut.String()
+		PushStyleVarVec2(imgui.StyleVarButtonTextAlign, imgui.Vec2{X: 0, Y: 0.5})
+		clicked = imgui.ButtonV(b.text, shortcutButtonSize(b.text, hint, size))
+		PopStyleVar(1)
+		drawShortcutHint(hint)
+
+		// A disabled button keeps its chord but ignores it
+		onClick := b.onClick
+		if b.disabledReason != "" {
+			onClick = nil
+		}
+		RegisterShortcut(*b.shortcut, onClick)
 	} else {
-		clicked = imgui.Button(b.text)
+		clicked = imgui.ButtonV(b.text, size)
+	}
+
+	if b.disabledReason != "" {
+		endDisabledWithReason(b.disabledReason)
+	}
+	applyHoverCursor(b.cursor)
+	if b.badge != nil {
+		b.badge.Build()
 	}
 	if clicked && b.onClick != nil {
 		b.onClick()
@@ -218,33 +488,344 @@ func (b *ButtonWidget) Size(width, height float32) *ButtonWidget {
 	return b
 }
 
+// ColumnSizing selects how a Row column gets its width
+type ColumnSizing int
+
+const (
+	ColumnStretch ColumnSizing = iota // share the remaining width by weight
+	ColumnAuto                        // fit the content
+	ColumnFixed                       // fixed width in pixels
+)
+
+// Alignment positions a widget inside the space available to it
+type Alignment int
+
+const (
+	AlignLeft Alignment = iota
+	AlignCenter
+	AlignRight
+)
+
+// RowColumn describes the sizing and alignment of one Row cell
+type RowColumn struct {
+	sizing  ColumnSizing
+	size    float32 // width for ColumnFixed, weight for ColumnStretch
+	align   Alignment
+	minSize float32 // 0 for no limit
+	maxSize float32 // 0 for no limit
+}
+
+// StretchColumn shares the remaining width with other stretch columns by weight
+func StretchColumn(weight float32) RowColumn {
+	return RowColumn{sizing: ColumnStretch, size: weight}
+}
+
+// AutoColumn sizes the column to its content
+func AutoColumn() RowColumn {
+	return RowColumn{sizing: ColumnAuto}
+}
+
+// FixedColumn gives the column a fixed width in pixels
+func FixedColumn(width float32) RowColumn {
+	return RowColumn{sizing: ColumnFixed, size: width}
+}
+
+// Align sets the horizontal alignment of the cell content
+func (c RowColumn) Align(align Alignment) RowColumn {
+	c.align = align
+	return c
+}
+
+// MinSize keeps the column at least width pixels wide; a row that cannot
+// fit its minimums grows wider than the window instead of crushing them
+func (c RowColumn) MinSize(width float32) RowColumn {
+	c.minSize = width
+	return c
+}
+
+// MaxSize keeps the column at most width pixels wide; the width it gives up
+// goes to the other stretch columns
+func (c RowColumn) MaxSize(width float32) RowColumn {
+	c.maxSize = width
+	return c
+}
+
+// clamp limits width to the column's minimum and maximum
+func (c RowColumn) clamp(width float32) float32 {
+	if c.maxSize > 0 {
+		width = min(width, c.maxSize)
+	}
+	if c.minSize > 0 {
+		width = max(width, c.minSize)
+	}
+	return width
+}
+
+// rowState keeps the cell sizes measured last frame, used for alignment
+type rowState struct {
+	cellSizes []imgui.Vec2
+	rowHeight float32
+}
+
+func (s *rowState) Dispose() {
+	s.cellSizes = nil
+}
+
 type RowWidget struct {
 	Widgets []Widget
+
+	id             string
+	columns        []RowColumn
+	verticalCenter bool
 }
 
 func Row(widgets ...Widget) *RowWidget {
-	row := &RowWidget{Widgets: widgets}
+	row := &RowWidget{Widgets: widgets, id: "#row_table"}
 	return row
 }
 
+// ID sets the table ID; give sibling rows distinct IDs (builder pattern)
+func (r *RowWidget) ID(id string) *RowWidget {
+	r.id = id
+	return r
+}
+
+// Columns sets per-cell sizing and alignment; cells without a spec stretch (builder pattern)
+func (r *RowWidget) Columns(columns ...RowColumn) *RowWidget {
+	r.columns = columns
+	return r
+}
+
+// VerticalCenter centers every cell vertically within the row (builder pattern)
+func (r *RowWidget) VerticalCenter(center bool) *RowWidget {
+	r.verticalCenter = center
+	return r
+}
+
+func (r *RowWidget) getState() *rowState {
+	id := fmt.Sprintf("##row_%d", imgui.IDStr(r.id))
+	if existingState, exists := GlobalContext.stateMap[id]; exists {
+		if state, ok := existingState.(*rowState); ok {
+			return state
+		}
+	}
+
+	newState := &rowState{}
+	GlobalContext.stateMap[id] = newState
+	return newState
+}
+
+// column returns the spec for cell i, stretching by default
+func (r *RowWidget) column(i int) RowColumn {
+	if i < len(r.columns) {
+		return r.columns[i]
+	}
+	return StretchColumn(1)
+}
+
+// constrained reports whether any column has a minimum or maximum size
+func (r *RowWidget) constrained() bool {
+	for _, column := range r.columns {
+		if column.minSize > 0 || column.maxSize > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// columnWidths resolves every column's width within the limits. Fixed and
+// auto columns are clamped first, auto ones using last frame's content
+// width; stretch columns share the rest by weight, and when one hits a
+// limit the others share what it leaves.
+func (r *RowWidget) columnWidths(state *rowState) []float32 {
+	widths := make([]float32, len(r.Widgets))
+	padding := imgui.CurrentStyle().CellPadding().X * 2
+	remaining := imgui.ContentRegionAvail().X - padding*float32(len(widths))
+
+	var stretch []int
+	for i := range widths {
+		column := r.column(i)
+		switch column.sizing {
+		case ColumnFixed:
+			widths[i] = column.clamp(column.size)
+		case ColumnAuto:
+			content := float32(0)
+			if i < len(state.cellSizes) {
+				content = state.cellSizes[i].X
+			}
+			widths[i] = column.clamp(content)
+		default:
+			stretch = append(stretch, i)
+			continue
+		}
+		remaining -= widths[i]
+	}
+
+	for len(stretch) > 0 {
+		totalWeight := float32(0)
+		for _, i := range stretch {
+			totalWeight += r.column(i).size
+		}
+		if totalWeight <= 0 {
+			break
+		}
+		share := max(remaining, 0) / totalWeight
+
+		var free []int
+		for _, i := range stretch {
+			column := r.column(i)
+			width := share * column.size
+			if clamped := column.clamp(width); clamped != width {
+				widths[i] = clamped
+				remaining -= clamped
+			} else {
+				widths[i] = width
+				free = append(free, i)
+			}
+		}
+		if len(free) == len(stretch) {
+			break
+		}
+		stretch = free
+	}
+	return widths
+}
+
+// isFramed reports whether a widget draws its text inside a frame, which
+// puts the text FramePadding.Y lower than a plain label's
+func isFramed(widget Widget) bool {
+	switch widget.(type) {
+	case *ButtonWidget, *InputTextWidget, *CheckboxWidget, *SliderWidget,
+		*ColorEditWidget, *ComboWidget, *CounterWidget:
+		return true
+	}
+	return false
+}
+
+// alignsText reports whether the row mixes labels with framed widgets, so
+// the labels must be lowered onto the framed text's baseline
+func (r *RowWidget) alignsText() bool {
+	hasLabel, hasFramed := false, false
+	for _, widget := range r.Widgets {
+		if isFramed(widget) {
+			hasFramed = true
+		} else if _, ok := widget.(*LabelWidget); ok {
+			hasLabel = true
+		}
+	}
+	return hasLabel && hasFramed
+}
+
+// needsMeasuring reports whether cells must be measured for alignment or
+// size limits
+func (r *RowWidget) needsMeasuring() bool {
+	if r.verticalCenter || r.constrained() {
+		return true
+	}
+	for _, column := range r.columns {
+		if column.align != AlignLeft {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *RowWidget) Build() {
 	if len(r.Widgets) == 0 {
 		return
 	}
 
+	// Size limits are resolved here and handed to the table as fixed widths
+	var widths []float32
+	var outerSize imgui.Vec2
+	if r.constrained() {
+		widths = r.columnWidths(r.getState())
+		total := imgui.CurrentStyle().CellPadding().X * 2 * float32(len(widths))
+		for _, width := range widths {
+			total += width
+		}
+		if total > imgui.ContentRegionAvail().X {
+			outerSize.X = total
+		}
+	}
+
 	// For simple horizontal layout, use a table
-	if imgui.BeginTableV("#row_table", int32(len(r.Widgets)), imgui.TableFlagsNone, imgui.Vec2{}, 0.0) {
+	if imgui.BeginTableV(r.id, int32(len(r.Widgets)), imgui.TableFlagsNone, outerSize, 0.0) {
+		if widths != nil {
+			for _, width := range widths {
+				imgui.TableSetupColumnV("", imgui.TableColumnFlagsWidthFixed, width, 0)
+			}
+		} else if len(r.columns) > 0 {
+			for i := range r.Widgets {
+				column := r.column(i)
+				switch column.sizing {
+				case ColumnAuto:
+					imgui.TableSetupColumnV("", imgui.TableColumnFlagsWidthFixed, 0, 0)
+				case ColumnFixed:
+					imgui.TableSetupColumnV("", imgui.TableColumnFlagsWidthFixed, column.size, 0)
+				default:
+					imgui.TableSetupColumnV("", imgui.TableColumnFlagsWidthStretch, column.size, 0)
+				}
+			}
+		}
+
 		imgui.TableNextRow()
 
-		for _, widget := range r.Widgets {
-			imgui.TableNextColumn()
-			widget.Build()
+		alignText := r.alignsText()
+		if !r.needsMeasuring() {
+			for _, widget := range r.Widgets {
+				imgui.TableNextColumn()
+				if alignText {
+					imgui.AlignTextToFramePadding()
+				}
+				buildWidget(widget)
+			}
+		} else {
+			r.buildAligned(alignText)
 		}
 
 		imgui.EndTable()
 	}
 }
 
+// buildAligned builds the cells, offsetting each one using the size it had
+// last frame
+func (r *RowWidget) buildAligned(alignText bool) {
+	state := r.getState()
+	if len(state.cellSizes) != len(r.Widgets) {
+		state.cellSizes = make([]imgui.Vec2, len(r.Widgets))
+	}
+
+	rowHeight := float32(0)
+	for i, widget := range r.Widgets {
+		imgui.TableNextColumn()
+
+		size := state.cellSizes[i]
+		switch r.column(i).align {
+		case AlignCenter:
+			imgui.SetCursorPosX(imgui.CursorPosX() + (imgui.ContentRegionAvail().X-size.X)/2)
+		case AlignRight:
+			imgui.SetCursorPosX(imgui.CursorPosX() + imgui.ContentRegionAvail().X - size.X)
+		}
+		if r.verticalCenter && state.rowHeight > size.Y {
+			imgui.SetCursorPosY(imgui.CursorPos().Y + (state.rowHeight-size.Y)/2)
+		}
+
+		imgui.BeginGroup()
+		if alignText {
+			imgui.AlignTextToFramePadding()
+		}
+		buildWidget(widget)
+		imgui.EndGroup()
+
+		state.cellSizes[i] = imgui.ItemRectSize()
+		if state.cellSizes[i].Y > rowHeight {
+			rowHeight = state.cellSizes[i].Y
+		}
+	}
+	state.rowHeight = rowHeight
+}
+
 type SpacingWidget struct{}
 
 func Spacing() *SpacingWidget {
@@ -255,6 +836,58 @@ func (s *SpacingWidget) Build() {
 	imgui.Spacing()
 }
 
+// SameLineWidget places the next widget on the same line as the previous one
+type SameLineWidget struct {
+	offset  float32
+	spacing float32
+}
+
+// SameLine creates a same-line directive with default spacing
+func SameLine() *SameLineWidget {
+	return &SameLineWidget{offset: 0, spacing: -1}
+}
+
+// Offset positions the next widget at x pixels from the window's content start (builder pattern)
+func (s *SameLineWidget) Offset(offset float32) *SameLineWidget {
+	s.offset = offset
+	return s
+}
+
+// Spacing sets the horizontal gap to the previous widget (builder pattern)
+func (s *SameLineWidget) Spacing(spacing float32) *SameLineWidget {
+	s.spacing = spacing
+	return s
+}
+
+func (s *SameLineWidget) Build() {
+	imgui.SameLineV(s.offset, s.spacing)
+}
+
+// DummyWidget reserves empty space in the layout
+type DummyWidget struct {
+	width  float32
+	height float32
+}
+
+func Dummy(width, height float32) *DummyWidget {
+	return &DummyWidget{width: width, height: height}
+}
+
+func (d *DummyWidget) Build() {
+	imgui.Dummy(imgui.Vec2{X: d.width, Y: d.height})
+}
+
+// NewLineWidget ends the current line, undoing a SameLine
+type NewLineWidget struct{}
+
+func NewLine() *NewLineWidget {
+	return &NewLineWidget{}
+}
+
+func (n *NewLineWidget) Build() {
+	imgui.NewLine()
+}
+
 // HotkeyWidget handles global keyboard shortcuts
 type HotkeyWidget struct {
 	key      int
@@ -327,8 +960,9 @@ func (s *SeparatorWidget) Build() {
 	imgui.Separator()
 }
 
-// NewMasterWindow creates a new master window
-func NewMasterWindow(title string, width, height int) *MasterWindow {
+// NewMasterWindow creates a new master window. It fails if the graphics
+// backend cannot be initialized.
+func NewMasterWindow(title string, width, height int) (*MasterWindow, error) {
 	runtime.LockOSThread() // Required for OpenGL context
 
 	// Create ImGui context
@@ -340,50 +974,108 @@ func NewMasterWindow(title string, width, height int) *MasterWindow {
 	// Create the backend wrapper
 	backendInstance, err := backend.CreateBackend(glfwBackend)
 	if err != nil {
-		panic(err)
+		imgui.DestroyContext()
+		return nil, fmt.Errorf("creating window backend: %w", err)
 	}
 
 	// Create the window
 	backendInstance.CreateWindow(title, width, height)
 
-	return &MasterWindow{
+	w := &MasterWindow{
 		backend: backendInstance,
 		title:   title,
 		width:   width,
 		height:  height,
+		fonts:   &FontManager{},
 	}
+
+	// The font atlas can only change between frames
+	backendInstance.SetBeforeRenderHook(func() {
+		w.fonts.update(w)
+	})
+
+	// Built-in subsystems integrate through the frame hooks
+	w.BeforeFrame(w.processActivations)
+	w.BeforeFrame(processShortcuts)
+	w.BeforeFrame(processUIQueue)
+	w.BeforeFrame(processTextureCaches)
+	w.BeforeFrame(checkKeyboardNavigation)
+	w.BeforeFrame(releaseCtrlTab)
+	w.AfterFrame(drawOverlays)
+	w.AfterFrame(drawDialogs)
+	w.AfterFrame(drawLayoutDebug)
+	w.AfterFrame(endDiagnosticsFrame)
+	w.AfterFrame(resetProgressBarIDs)
+	w.AfterFrame(endProfileFrame)
+	w.BeforeFrame(func() {
+		if remoteServer != nil {
+			remoteServer.processCalls()
+		}
+	})
+
+	return w, nil
+}
+
+// BeforeFrame registers a hook run at the start of every frame, before the
+// user's loop function (builder pattern)
+func (w *MasterWindow) BeforeFrame(hook func()) *MasterWindow {
+	w.beforeFrame = append(w.beforeFrame, hook)
+	return w
 }
 
-// FIXED: Proper theme application in the main loop
-func (w *MasterWindow) Run(loopFunc func()) {
+// AfterFrame registers a hook run at the end of every frame, after the
+// user's loop function (builder pattern)
+func (w *MasterWindow) AfterFrame(hook func()) *MasterWindow {
+	w.afterFrame = append(w.afterFrame, hook)
+	return w
+}
+
+// Run shows the window and calls loopFunc every frame until it is closed
+func (w *MasterWindow) Run(loopFunc func()) error {
+	return w.RunE(func() error {
+		loopFunc()
+		return nil
+	})
+}
+
+// RunE is Run for loops that can fail: the first error loopFunc returns
+// closes the window and is returned
+func (w *MasterWindow) RunE(loopFunc func() error) error {
+	var runErr error
 	w.backend.Run(func() {
 		// Apply global theme at the start of each frame
-		var colorCount, varCount int32
+		var scope themeScope
 		if currentThemeObject != nil {
-			// Push theme colors
-			for colorID, color := range currentThemeObject.colors {
-				imgui.PushStyleColorVec4(imgui.Col(colorID), color)
-				colorCount++
-			}
+			scope = currentThemeObject.push()
+		}
 
-			// Push theme variables
-			for varID, value := range currentThemeObject.vars {
-				imgui.PushStyleVarFloat(imgui.StyleVar(varID), value)
-				varCount++
+		for _, hook := range w.beforeFrame {
+			hook()
+		}
+
+		// Execute user's UI definition, unless the splash screen still covers
+		// it or a crash is being reported
+		if report := currentCrash(); report != nil {
+			w.buildCrashDialog(report)
+		} else if runErr == nil && (w.splash == nil || w.splash.render(w)) {
+			if err := runGuarded(loopFunc); err != nil {
+				runErr = err
+				w.backend.SetShouldClose(true)
 			}
 		}
 
-		// Execute user's UI definition
-		loopFunc()
+		for _, hook := range w.afterFrame {
+			hook()
+		}
 
 		// Pop theme styles at the end of the frame
-		if varCount > 0 {
-			imgui.PopStyleVarV(varCount)
-		}
-		if colorCount > 0 {
-			imgui.PopStyleColorV(colorCount)
-		}
+		scope.pop()
 	})
+
+	if appInstance != nil {
+		appInstance.close()
+	}
+	return runErr
 }
 
 func onHelloClick() {
@@ -395,11 +1087,17 @@ func onGoodbyeClick() {
 }
 
 type InputTextWidget struct {
-	id       string
-	label    string
-	text     *string
-	width    float32
-	onChange func()
+	id          string
+	label       string
+	text        *string
+	width       float32
+	onChange    func()
+	onSubmit    func(text string)
+	history     int
+	completions func(prefix string) []string
+	validators  []func(text string) error
+
+	disabledReason string
 }
 
 func InputText(label string, text *string) *InputTextWidget {
@@ -423,29 +1121,98 @@ func (i *InputTextWidget) OnChange(onChange func()) *InputTextWidget {
 	return i
 }
 
-func (i *InputTextWidget) Build() {
-	if i.width > 0 {
-		imgui.SetNextItemWidth(i.width)
+// OnSubmit sets the callback invoked when Enter is pressed in the field (builder pattern)
+func (i *InputTextWidget) OnSubmit(onSubmit func(text string)) *InputTextWidget {
+	i.onSubmit = onSubmit
+	return i
+}
+
+func (i *InputTextWidget) Build() {
+	if i.text == nil {
+		ReportDiagnostic(fmt.Sprintf("input %q has no bound value", i.label))
+		return
+	}
+
+	defaults := beginDefaults(KindInputText)
+	defer defaults.end()
+
+	if i.width > 0 {
+		imgui.SetNextItemWidth(i.width)
+	} else if defaults.width > 0 {
+		imgui.SetNextItemWidth(defaults.width)
+	}
+
+	var flags imgui.InputTextFlags
+	if i.onSubmit != nil || i.history > 0 {
+		flags |= imgui.InputTextFlagsEnterReturnsTrue
+	}
+	var history *inputHistoryState
+	if i.history > 0 {
+		history = i.getHistory()
+		flags |= imgui.InputTextFlagsCallbackHistory
+	}
+	var completion *completionState
+	if i.completions != nil {
+		completion = i.getCompletion()
+		completion.beginFrame()
+		flags |= imgui.InputTextFlagsCallbackHistory | imgui.InputTextFlagsCallbackCompletion | imgui.InputTextFlagsCallbackAlways
+	}
+
+	var callback imgui.InputTextCallback
+	if history != nil || completion != nil {
+		callback = inputCallback(history, completion, *i.text)
 	}
 
 	oldText := *i.text
-	changed := imgui.InputTextWithHint(i.id, "", i.text, 0, nil)
+	if i.disabledReason != "" {
+		imgui.BeginDisabled()
+	}
+	entered := imgui.InputTextWithHint(i.id, "", i.text, flags, callback)
+	if i.disabledReason != "" {
+		endDisabledWithReason(i.disabledReason)
+	}
+	i.validate()
 
-	if changed && oldText != *i.text && i.onChange != nil {
+	if oldText != *i.text && i.onChange != nil {
 		i.onChange()
 	}
+	if completion != nil {
+		completion.update(*i.text, oldText != *i.text, i.completions)
+		if completion.accepted {
+			// Enter picked a suggestion rather than submitting the line
+			return
+		}
+	}
+	if entered && flags&imgui.InputTextFlagsEnterReturnsTrue != 0 {
+		if history != nil {
+			history.add(*i.text, i.history)
+		}
+		if i.onSubmit != nil {
+			i.onSubmit(*i.text)
+		}
+	}
 }
 
 // Context manages global state for our GUI framework
 type Context struct {
 	widgetCounter int
 	stateMap      map[string]interface{}
+
+	// Default styles per widget kind, and the StyleSetters currently building
+	defaultStyles  map[WidgetKind]*WidgetDefaults
+	styleOverrides []*StyleSetter
+
+	// Popups to open when next built, and a close request for the current one
+	pendingPopups []string
+	closePopup    bool
+	popupDepth    int // popup layouts being built, see ClosePopup
 }
 
 // Global context instance
 var GlobalContext = &Context{
 	widgetCounter: 0,
 	stateMap:      make(map[string]interface{}),
+	defaultStyles: make(map[WidgetKind]*WidgetDefaults),
 }
 
 // GenAutoID generates unique IDs for widgets
@@ -459,6 +1226,8 @@ type CheckboxWidget struct {
 	onChange func()
 	label    string
 	checked  *bool
+
+	disabledReason string
 }
 
 func Checkbox(label string, checked *bool) *CheckboxWidget {
@@ -478,11 +1247,20 @@ func (c *CheckboxWidget) OnChange(fn func()) *CheckboxWidget {
 
 func (c *CheckboxWidget) Build() {
 	if c.checked == nil {
-		panic("c.checked is nil in Build method!")
+		ReportDiagnostic(fmt.Sprintf("checkbox %q has no bound value", c.label))
+		return
 	}
 
+	defer beginDefaults(KindCheckbox).end()
+
 	oldValue := *c.checked
-	imgui.Checkbox(c.label, c.checked)
+	if c.disabledReason != "" {
+		imgui.BeginDisabled()
+		imgui.Checkbox(c.label, c.checked)
+		endDisabledWithReason(c.disabledReason)
+	} else {
+		imgui.Checkbox(c.label, c.checked)
+	}
 
 	if oldValue != *c.checked && c.onChange != nil {
 		fmt.Printf("Checkbox changed from %t to %t, calling onChange\n", oldValue, *c.checked)
@@ -493,6 +1271,7 @@ func (c *CheckboxWidget) Build() {
 // SingleWindowWidget fills the entire master window
 type SingleWindowWidget struct {
 	widgets []Widget
+	theme   *Theme
 }
 
 func SingleWindow() *SingleWindowWidget {
@@ -507,9 +1286,10 @@ func (s *SingleWindowWidget) Layout(widgets ...Widget) *SingleWindowWidget {
 }
 
 func (s *SingleWindowWidget) Build() {
+	// The work area excludes the main menu bar
 	viewport := imgui.MainViewport()
-	pos := viewport.Pos()
-	size := viewport.Size()
+	pos := viewport.WorkPos()
+	size := viewport.WorkSize()
 
 	imgui.SetNextWindowPos(pos)
 	imgui.SetNextWindowSize(size)
@@ -519,33 +1299,43 @@ func (s *SingleWindowWidget) Build() {
 		imgui.WindowFlagsNoMove |
 		imgui.WindowFlagsNoCollapse |
 		imgui.WindowFlagsNoScrollbar
+	for _, widget := range s.widgets {
+		if _, ok := widget.(*MenuBarWidget); ok {
+			flags |= imgui.WindowFlagsMenuBar
+		}
+	}
+
+	// The window background is read at Begin, so the theme wraps the whole window
+	var scope themeScope
+	if s.theme != nil {
+		scope = s.theme.push()
+	}
 
 	imgui.BeginV("##SingleWindow", nil, imgui.WindowFlags(flags))
 
 	for _, widget := range s.widgets {
-		if widget != nil {
-			widget.Build()
-		}
+		buildWidget(widget)
 	}
 
 	imgui.End()
+	scope.pop()
 }
 
 // ColumnWidget arranges widgets vertically
 type ColumnWidget struct {
-	widgets []Widget
+	id       string
+	widgets  []Widget
+	width    float32
+	height   float32
+	overflow Overflow
 }
 
 func Column(widgets ...Widget) *ColumnWidget {
-	return &ColumnWidget{widgets: widgets}
+	return &ColumnWidget{id: "##column", widgets: widgets}
 }
 
 func (c *ColumnWidget) Build() {
-	for _, widget := range c.widgets {
-		if widget != nil {
-			widget.Build()
-		}
-	}
+	buildOverflow(c.id, c.overflow, imgui.Vec2{X: c.width, Y: c.height}, c.widgets)
 }
 
 // SliderWidget represents a value slider
@@ -574,6 +1364,17 @@ func (s *SliderWidget) OnChange(onChange func()) *SliderWidget {
 }
 
 func (s *SliderWidget) Build() {
+	defaults := beginDefaults(KindSlider)
+	defer defaults.end()
+
+	if defaults.width > 0 {
+		imgui.SetNextItemWidth(defaults.width)
+	}
+
+	if s.value == nil {
+		ReportDiagnostic(fmt.Sprintf("slider %q has no bound value", s.label))
+		return
+	}
 	oldValue := *s.value
 
 	if imgui.SliderFloatV(s.label, s.value, s.min, s.max, "%.2f", 0) {
@@ -606,6 +1407,17 @@ func (c *ColorEditWidget) OnChange(onChange func()) *ColorEditWidget {
 }
 
 func (c *ColorEditWidget) Build() {
+	defaults := beginDefaults(KindColorEdit)
+	defer defaults.end()
+
+	if defaults.width > 0 {
+		imgui.SetNextItemWidth(defaults.width)
+	}
+
+	if c.color == nil {
+		ReportDiagnostic(fmt.Sprintf("color edit %q has no bound value", c.label))
+		return
+	}
 	oldColor := *c.color
 
 	if imgui.ColorEdit3V(c.label, c.color, 0) {
@@ -615,16 +1427,74 @@ func (c *ColorEditWidget) Build() {
 	}
 }
 
+// IndeterminateStyle selects the animation of a progress bar without known progress
+type IndeterminateStyle int
+
+const (
+	ProgressDeterminate IndeterminateStyle = iota
+	ProgressStriped
+	ProgressPulsing
+)
+
+// progressSample is a point in a progress bar's history
+type progressSample struct {
+	time     time.Time
+	progress float32
+}
+
+// progressState keeps the recent history used to estimate remaining time
+type progressState struct {
+	samples []progressSample
+}
+
+func (s *progressState) Dispose() {
+	s.samples = nil
+}
+
+// etaWindow is how much progress history the ETA estimate looks at
+const etaWindow = 5 * time.Second
+
+// record adds a sample and returns the estimated time remaining, or false
+// while there isn't enough history for an estimate
+func (s *progressState) record(progress float32) (time.Duration, bool) {
+	now := time.Now()
+
+	// Progress went backwards, the task was restarted
+	if len(s.samples) > 0 && progress < s.samples[len(s.samples)-1].progress {
+		s.samples = s.samples[:0]
+	}
+	s.samples = append(s.samples, progressSample{time: now, progress: progress})
+
+	for len(s.samples) > 2 && now.Sub(s.samples[0].time) > etaWindow {
+		s.samples = s.samples[1:]
+	}
+
+	oldest := s.samples[0]
+	elapsed := now.Sub(oldest.time)
+	done := progress - oldest.progress
+	if elapsed < 500*time.Millisecond || done <= 0 {
+		return 0, false
+	}
+
+	rate := float64(done) / elapsed.Seconds()
+	return time.Duration(float64(1-progress) / rate * float64(time.Second)), true
+}
+
 // ProgressBarWidget represents a progress bar
 type ProgressBarWidget struct {
-	progress float32
-	width    float32
-	height   float32
-	overlay  string
+	id            string
+	progress      float32
+	width         float32
+	height        float32
+	overlay       string
+	showETA       bool
+	onCancel      context.CancelFunc
+	indeterminate IndeterminateStyle
 }
 
 func ProgressBar(progress float32) *ProgressBarWidget {
 	return &ProgressBarWidget{
+		id:       "##progress",
 		progress: progress,
 		width:    -1,
 		height:   0,
@@ -642,27 +1512,180 @@ func (p *ProgressBarWidget) Overlay(text string) *ProgressBarWidget {
 	return p
 }
 
+// ID sets a stable ID, needed to keep ETA history and cancel buttons apart (builder pattern)
+func (p *ProgressBarWidget) ID(id string) *ProgressBarWidget {
+	p.id = id
+	return p
+}
+
+// ShowETA overlays the estimated time remaining, computed from recent progress (builder pattern)
+func (p *ProgressBarWidget) ShowETA(show bool) *ProgressBarWidget {
+	p.showETA = show
+	return p
+}
+
+// OnCancel adds a Cancel button next to the bar which calls cancel (builder pattern)
+func (p *ProgressBarWidget) OnCancel(cancel context.CancelFunc) *ProgressBarWidget {
+	p.onCancel = cancel
+	return p
+}
+
+// Indeterminate animates the bar instead of showing progress (builder pattern)
+func (p *ProgressBarWidget) Indeterminate(style IndeterminateStyle) *ProgressBarWidget {
+	p.indeterminate = style
+	return p
+}
+
+func (p *ProgressBarWidget) getState() *progressState {
+	if existingState, exists := GlobalContext.stateMap[p.id]; exists {
+		if state, ok := existingState.(*progressState); ok {
+			return state
+		}
+	}
+
+	newState := &progressState{}
+	GlobalContext.stateMap[p.id] = newState
+	return newState
+}
+
+// IDs of the stateful progress bars built this frame, to catch bars in the
+// same ID scope sharing the default ID
+var progressBarIDs = make(map[imgui.ID]bool)
+
+// resetProgressBarIDs runs after each frame
+func resetProgressBarIDs() {
+	clear(progressBarIDs)
+}
+
 func (p *ProgressBarWidget) Build() {
+	defaults := beginDefaults(KindProgressBar)
+	defer defaults.end()
+
+	// ETA history and the cancel button are keyed by the ID
+	if p.showETA || p.onCancel != nil {
+		id := imgui.IDStr(p.id)
+		if progressBarIDs[id] {
+			ReportDiagnostic(fmt.Sprintf("progress bars share the id %q; give each one an ID", p.id))
+		}
+		progressBarIDs[id] = true
+	}
+
 	size := imgui.Vec2{X: p.width, Y: p.height}
-	imgui.ProgressBarV(p.progress, size, p.overlay)
+	if p.width < 0 && defaults.width > 0 {
+		size.X = defaults.width
+	}
+
+	// Leave room for the cancel button on the same line
+	cancelLabel := fmt.Sprintf("Cancel##%s_cancel", p.id)
+	if p.onCancel != nil && size.X <= 0 {
+		size.X = imgui.ContentRegionAvail().X - imgui.CalcTextSize("Cancel").X -
+			imgui.CurrentStyle().FramePadding().X*2 - imgui.CurrentStyle().ItemSpacing().X
+	}
+
+	if p.indeterminate != ProgressDeterminate {
+		p.buildIndeterminate(size)
+	} else {
+		overlay := p.overlay
+		if p.showETA {
+			if remaining, ok := p.getState().record(p.progress); ok {
+				eta := fmt.Sprintf("%s left", remaining.Round(time.Second))
+				if overlay != "" {
+					overlay += " - " + eta
+				} else {
+					overlay = eta
+				}
+			}
+		}
+		imgui.ProgressBarV(p.progress, size, overlay)
+	}
+
+	if p.onCancel != nil {
+		imgui.SameLine()
+		if imgui.Button(cancelLabel) {
+			p.onCancel()
+		}
+	}
+}
+
+// buildIndeterminate draws an animated bar for work of unknown length
+func (p *ProgressBarWidget) buildIndeterminate(size imgui.Vec2) {
+	if size.X <= 0 {
+		size.X = imgui.ContentRegionAvail().X
+	}
+	if size.Y <= 0 {
+		size.Y = imgui.FrameHeight()
+	}
+
+	min := imgui.CursorScreenPos()
+	max := min.Add(size)
+	imgui.Dummy(size)
+
+	drawList := imgui.WindowDrawList()
+	fill := *imgui.StyleColorVec4(imgui.ColPlotHistogram)
+	drawList.AddRectFilledV(min, max, imgui.ColorU32Col(imgui.ColFrameBg), 3.0, imgui.DrawFlagsRoundCornersAll)
+	drawList.PushClipRectV(min, max, true)
+
+	t := float32(imgui.Time())
+	switch p.indeterminate {
+	case ProgressStriped:
+		// Diagonal stripes scrolling to the right
+		stripe := size.Y
+		offset := float32(math.Mod(float64(t*size.Y*2), float64(stripe*2)))
+		color := imgui.ColorU32Vec4(fill)
+		for x := min.X - size.Y - stripe*2 + offset; x < max.X; x += stripe * 2 {
+			drawList.AddQuadFilled(
+				imgui.Vec2{X: x, Y: max.Y},
+				imgui.Vec2{X: x + size.Y, Y: min.Y},
+				imgui.Vec2{X: x + size.Y + stripe, Y: min.Y},
+				imgui.Vec2{X: x + stripe, Y: max.Y},
+				color)
+		}
+
+	case ProgressPulsing:
+		// A block sliding back and forth while its brightness pulses
+		blockWidth := size.X * 0.3
+		phase := (float32(math.Sin(float64(t*2))) + 1) / 2
+		x := min.X + phase*(size.X-blockWidth)
+		alpha := 0.6 + 0.4*(float32(math.Sin(float64(t*6)))+1)/2
+		drawList.AddRectFilledV(imgui.Vec2{X: x, Y: min.Y}, imgui.Vec2{X: x + blockWidth, Y: max.Y},
+			imgui.ColorU32Vec4(WithAlpha(fill, fill.W*alpha)), 3.0, imgui.DrawFlagsRoundCornersAll)
+	}
+
+	drawList.PopClipRect()
+
+	if p.overlay != "" {
+		textSize := imgui.CalcTextSize(p.overlay)
+		textPos := imgui.Vec2{X: min.X + (size.X-textSize.X)/2, Y: min.Y + (size.Y-textSize.Y)/2}
+		drawList.AddTextVec2(textPos, imgui.ColorU32Col(imgui.ColText), p.overlay)
+	}
 }
 
 // counterState holds internal state for CounterWidget
 type counterState struct {
 	value int
-	step  int
+
+	// Direct numeric entry
+	editing   bool
+	editValue int32
 }
 
 func (s *counterState) Dispose() {
 	// Nothing to clean up for this simple state
 }
 
+func (s *counterState) Snapshot() any {
+	return map[string]any{"value": s.value}
+}
+
 // CounterWidget is a custom widget that manages its own counter state
 type CounterWidget struct {
 	id       string
 	label    string
 	minValue int
 	maxValue int
+	step     int
+	wrap     bool
+	binding  *int
 	onChange func(int)
 }
 
@@ -673,6 +1696,7 @@ func Counter(label string) *CounterWidget {
 		label:    label,
 		minValue: 0,
 		maxValue: 100,
+		step:     1,
 	}
 }
 
@@ -686,6 +1710,24 @@ func (c *CounterWidget) Max(max int) *CounterWidget {
 	return c
 }
 
+// Step sets how much +/- change the value (builder pattern)
+func (c *CounterWidget) Step(step int) *CounterWidget {
+	c.step = step
+	return c
+}
+
+// Wrap makes the value wrap around from max to min and vice versa (builder pattern)
+func (c *CounterWidget) Wrap(wrap bool) *CounterWidget {
+	c.wrap = wrap
+	return c
+}
+
+// Bind stores the value in an external variable instead of internal state (builder pattern)
+func (c *CounterWidget) Bind(value *int) *CounterWidget {
+	c.binding = value
+	return c
+}
+
 func (c *CounterWidget) OnChange(onChange func(int)) *CounterWidget {
 	c.onChange = onChange
 	return c
@@ -700,14 +1742,54 @@ func (c *CounterWidget) getState() *counterState {
 
 	newState := &counterState{
 		value: c.minValue,
-		step:  1,
 	}
 	GlobalContext.stateMap[c.id] = newState
 	return newState
 }
 
+// valuePtr returns where the value lives: the binding or the internal state
+func (c *CounterWidget) valuePtr() *int {
+	if c.binding != nil {
+		return c.binding
+	}
+	return &c.getState().value
+}
+
+// setValue stores a new value, applying wrap-around or clamping
+func (c *CounterWidget) setValue(value int) {
+	if value > c.maxValue {
+		if c.wrap {
+			value = c.minValue
+		} else {
+			value = c.maxValue
+		}
+	} else if value < c.minValue {
+		if c.wrap {
+			value = c.maxValue
+		} else {
+			value = c.minValue
+		}
+	}
+
+	current := c.valuePtr()
+	if value == *current {
+		return
+	}
+
+	oldValue := *current
+	*current = value
+	if c.onChange != nil {
+		c.onChange(value)
+	}
+	fmt.Printf("%s: %d -> %d\n", c.label, oldValue, value)
+}
+
 func (c *CounterWidget) Build() {
 	state := c.getState()
+	value := c.valuePtr()
+
+	imgui.PushIDStr(c.id)
+	defer imgui.PopID()
 
 	if imgui.BeginTableV("##counter_table", 4, imgui.TableFlagsNone, imgui.Vec2{}, 0.0) {
 		imgui.TableNextRow()
@@ -715,61 +1797,97 @@ func (c *CounterWidget) Build() {
 		imgui.TableNextColumn()
 		imgui.Text(c.label)
 
+		// Holding a button repeats it
+		imgui.PushItemFlag(imgui.ItemFlagsButtonRepeat, true)
+
 		imgui.TableNextColumn()
-		if imgui.Button("-") && state.value > c.minValue {
-			oldValue := state.value
-			state.value--
-			if c.onChange != nil {
-				c.onChange(state.value)
-			}
-			fmt.Printf("%s: %d -> %d\n", c.label, oldValue, state.value)
+		if imgui.Button("-") {
+			c.setValue(*value - c.step)
 		}
 
 		imgui.TableNextColumn()
-		imgui.Text(fmt.Sprintf(" %d ", state.value))
+		if state.editing {
+			imgui.SetNextItemWidth(80)
+			if !imgui.IsAnyItemActive() {
+				imgui.SetKeyboardFocusHere()
+			}
+			imgui.InputIntV("##edit", &state.editValue, 0, 0, imgui.InputTextFlagsCharsDecimal)
 
-		imgui.TableNextColumn()
-		if imgui.Button("+") && state.value < c.maxValue {
-			oldValue := state.value
-			state.value++
-			if c.onChange != nil {
-				c.onChange(state.value)
+			if imgui.IsKeyPressedBoolV(imgui.KeyEscape, false) {
+				state.editing = false
+			} else if imgui.IsItemDeactivated() {
+				state.editing = false
+				c.setValue(int(state.editValue))
 			}
-			fmt.Printf("%s: %d -> %d\n", c.label, oldValue, state.value)
+		} else if imgui.SelectableBoolV(fmt.Sprintf(" %d ##value", *value), false, imgui.SelectableFlagsNone, imgui.CalcTextSize(fmt.Sprintf(" %d ", *value))) {
+			// Click the value to type a number directly
+			state.editing = true
+			state.editValue = int32(*value)
+		}
+
+		imgui.TableNextColumn()
+		if imgui.Button("+") {
+			c.setValue(*value + c.step)
 		}
 
+		imgui.PopItemFlag()
+
 		imgui.EndTable()
 	}
 }
 
 func (c *CounterWidget) GetValue() int {
-	state := c.getState()
-	return state.value
+	return *c.valuePtr()
 }
 
 func (c *CounterWidget) SetValue(value int) {
-	state := c.getState()
 	if value >= c.minValue && value <= c.maxValue {
-		state.value = value
+		*c.valuePtr() = value
 	}
 }
 
 // timerState holds internal state for TimerWidget
 type timerState struct {
-	startTime   float64
-	elapsedTime float64
-	isRunning   bool
-	isPaused    bool
+	// Wall-clock timekeeping: time accumulated by earlier run segments plus
+	// the current segment, so pauses and frame hitches don't skew the result
+	segmentStart time.Time
+	accumulated  time.Duration
+	isRunning    bool
+	isPaused     bool
+	expired      bool
+	laps         []time.Duration
 }
 
 func (s *timerState) Dispose() {
-	// Nothing to clean up
+	s.laps = nil
+}
+
+func (s *timerState) Snapshot() any {
+	return map[string]any{
+		"elapsed": s.elapsed().String(),
+		"running": s.isRunning,
+		"paused":  s.isPaused,
+		"expired": s.expired,
+		"laps":    len(s.laps),
+	}
+}
+
+// elapsed returns the total running time
+func (s *timerState) elapsed() time.Duration {
+	if s.isRunning && !s.isPaused {
+		return s.accumulated + time.Since(s.segmentStart)
+	}
+	return s.accumulated
 }
 
 // TimerWidget shows elapsed time with start/stop/reset controls
 type TimerWidget struct {
-	id    string
-	label string
+	id        string
+	label     string
+	countdown time.Duration
+	format    string
+	showLaps  bool
+	onExpire  func()
 }
 
 func Timer(label string) *TimerWidget {
@@ -779,6 +1897,31 @@ func Timer(label string) *TimerWidget {
 	}
 }
 
+// CountdownFrom turns the timer into a countdown from d (builder pattern)
+func (t *TimerWidget) CountdownFrom(d time.Duration) *TimerWidget {
+	t.countdown = d
+	return t
+}
+
+// OnExpire sets the callback for when a countdown reaches zero (builder pattern)
+func (t *TimerWidget) OnExpire(onExpire func()) *TimerWidget {
+	t.onExpire = onExpire
+	return t
+}
+
+// Format sets the display format using hh, mm, ss and cc (centiseconds)
+// placeholders, e.g. "mm:ss.cc" (builder pattern)
+func (t *TimerWidget) Format(format string) *TimerWidget {
+	t.format = format
+	return t
+}
+
+// Laps adds a Lap button and shows the recorded lap times (builder pattern)
+func (t *TimerWidget) Laps(show bool) *TimerWidget {
+	t.showLaps = show
+	return t
+}
+
 func (t *TimerWidget) getState() *timerState {
 	if existingState, exists := GlobalContext.stateMap[t.id]; exists {
 		if state, ok := existingState.(*timerState); ok {
@@ -786,44 +1929,95 @@ func (t *TimerWidget) getState() *timerState {
 		}
 	}
 
-	newState := &timerState{
-		startTime:   imgui.Time(),
-		elapsedTime: 0.0,
-		isRunning:   false,
-		isPaused:    false,
-	}
+	newState := &timerState{}
 	GlobalContext.stateMap[t.id] = newState
 	return newState
 }
 
+// formatDuration renders d using the widget's format
+func (t *TimerWidget) formatDuration(d time.Duration) string {
+	if t.format == "" {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+
+	// Units missing from the format carry into the next smaller one shown,
+	// so "ss" alone counts every second and "mm" absorbs the hours
+	units := []struct {
+		placeholder string
+		centis      int64
+	}{
+		{"hh", 360000},
+		{"mm", 6000},
+		{"ss", 100},
+		{"cc", 1},
+	}
+
+	remaining := d.Milliseconds() / 10
+	replacements := make([]string, 0, len(units)*2)
+	for _, unit := range units {
+		if !strings.Contains(t.format, unit.placeholder) {
+			continue
+		}
+		replacements = append(replacements, unit.placeholder, fmt.Sprintf("%02d", remaining/unit.centis))
+		remaining %= unit.centis
+	}
+
+	return strings.NewReplacer(replacements...).Replace(t.format)
+}
+
 func (t *TimerWidget) Build() {
 	state := t.getState()
-	currentTime := imgui.Time()
-
-	if state.isRunning && !state.isPaused {
-		state.elapsedTime = currentTime - state.startTime
+	now := time.Now()
+
+	display := state.elapsed()
+	if t.countdown > 0 {
+		display = t.countdown - display
+		if display <= 0 {
+			display = 0
+			if state.isRunning {
+				state.isRunning = false
+				state.accumulated = t.countdown
+				state.expired = true
+				if t.onExpire != nil {
+					t.onExpire()
+				}
+			}
+		}
 	}
 
-	imgui.Text(fmt.Sprintf("%s: %.1fs", t.label, state.elapsedTime))
+	imgui.Text(fmt.Sprintf("%s: %s", t.label, t.formatDuration(display)))
+
+	imgui.PushIDStr(t.id)
+	defer imgui.PopID()
+
+	columns := int32(3)
+	if t.showLaps {
+		columns++
+	}
 
-	if imgui.BeginTableV("##timer_controls", 3, imgui.TableFlagsNone, imgui.Vec2{}, 0.0) {
+	if imgui.BeginTableV("##timer_controls", columns, imgui.TableFlagsNone, imgui.Vec2{}, 0.0) {
 		imgui.TableNextRow()
 
 		imgui.TableNextColumn()
 		if !state.isRunning {
 			if imgui.Button("Start") {
-				state.startTime = currentTime - state.elapsedTime
+				if state.expired {
+					state.accumulated = 0
+					state.expired = false
+				}
+				state.segmentStart = now
 				state.isRunning = true
 				state.isPaused = false
 			}
 		} else {
 			if !state.isPaused {
 				if imgui.Button("Pause") {
+					state.accumulated += now.Sub(state.segmentStart)
 					state.isPaused = true
 				}
 			} else {
 				if imgui.Button("Resume") {
-					state.startTime = currentTime - state.elapsedTime
+					state.segmentStart = now
 					state.isPaused = false
 				}
 			}
@@ -833,54 +2027,157 @@ func (t *TimerWidget) Build() {
 		if imgui.Button("Stop") {
 			state.isRunning = false
 			state.isPaused = false
-			state.elapsedTime = 0.0
+			state.accumulated = 0
+			state.expired = false
 		}
 
 		imgui.TableNextColumn()
 		if imgui.Button("Reset") {
-			state.startTime = currentTime
-			state.elapsedTime = 0.0
+			state.segmentStart = now
+			state.accumulated = 0
+			state.expired = false
+			state.laps = nil
+		}
+
+		if t.showLaps {
+			imgui.TableNextColumn()
+			if imgui.Button("Lap") && state.isRunning {
+				state.laps = append(state.laps, state.elapsed())
+			}
 		}
 
 		imgui.EndTable()
 	}
+
+	if t.showLaps {
+		previous := time.Duration(0)
+		for i, lap := range state.laps {
+			imgui.Text(fmt.Sprintf("Lap %d: %s (+%s)", i+1, t.formatDuration(lap), t.formatDuration(lap-previous)))
+			previous = lap
+		}
+	}
 }
 
 func (t *TimerWidget) GetElapsed() float64 {
 	state := t.getState()
-	return state.elapsedTime
+	return state.elapsed().Seconds()
+}
+
+// GetLaps returns the recorded lap times, measured from the start
+func (t *TimerWidget) GetLaps() []time.Duration {
+	return t.getState().laps
+}
+
+// StatusLevel is the severity of a status message
+type StatusLevel int
+
+const (
+	StatusInfo StatusLevel = iota
+	StatusWarn
+	StatusError
+)
+
+func (l StatusLevel) String() string {
+	switch l {
+	case StatusWarn:
+		return "WARN"
+	case StatusError:
+		return "ERROR"
+	default:
+		return "INFO"
+	}
+}
+
+// statusEntry is a single message in the status display
+type statusEntry struct {
+	message  string
+	level    StatusLevel
+	time     float64
+	wallTime time.Time
 }
 
 // statusState holds the status display state
 type statusState struct {
-	messages    []string
-	timestamps  []float64
+	entries     []statusEntry
 	maxMessages int
+	hidden      [3]bool // per-level filter toggles
+	logFile     *rotatingFile
 }
 
 func (s *statusState) Dispose() {
-	s.messages = nil
-	s.timestamps = nil
+	s.entries = nil
+	if s.logFile != nil {
+		s.logFile.Close()
+		s.logFile = nil
+	}
+}
+
+func (s *statusState) Snapshot() any {
+	messages := make([]string, len(s.entries))
+	for i, entry := range s.entries {
+		messages[i] = entry.format(true)
+	}
+	return map[string]any{"messages": messages}
 }
 
 // StatusDisplayWidget shows a scrolling list of status messages
 type StatusDisplayWidget struct {
-	id     string
-	height float32
+	id         string
+	height     float32
+	maxAge     float64
+	timestamps bool
+	filters    bool
 }
 
 func StatusDisplay() *StatusDisplayWidget {
 	return &StatusDisplayWidget{
 		id:     "##status_display",
 		height: 100,
+		maxAge: 10.0,
 	}
 }
 
+// Height sets the height of the scrolling message list; 0 or less draws the
+// messages inline (builder pattern)
 func (s *StatusDisplayWidget) Height(height float32) *StatusDisplayWidget {
 	s.height = height
 	return s
 }
 
+// MaxAge hides messages older than the given number of seconds; 0 keeps them all (builder pattern)
+func (s *StatusDisplayWidget) MaxAge(seconds float64) *StatusDisplayWidget {
+	s.maxAge = seconds
+	return s
+}
+
+// Timestamps shows wall-clock times instead of message ages (builder pattern)
+func (s *StatusDisplayWidget) Timestamps(show bool) *StatusDisplayWidget {
+	s.timestamps = show
+	return s
+}
+
+// Filters shows per-level filter toggles and a copy-all button (builder pattern)
+func (s *StatusDisplayWidget) Filters(show bool) *StatusDisplayWidget {
+	s.filters = show
+	return s
+}
+
+// TeeToFile also writes every message to a log file, rotating it once it
+// exceeds maxSize bytes and keeping up to maxBackups old files
+func (s *StatusDisplayWidget) TeeToFile(path string, maxSize int64, maxBackups int) error {
+	logFile, err := openRotatingFile(path, maxSize, maxBackups)
+	if err != nil {
+		return err
+	}
+
+	state := s.getState()
+	if state.logFile != nil {
+		state.logFile.Close()
+	}
+	state.logFile = logFile
+	return nil
+}
+
 func (s *StatusDisplayWidget) getState() *statusState {
 	if existingState, exists := GlobalContext.stateMap[s.id]; exists {
 		if state, ok := existingState.(*statusState); ok {
@@ -889,24 +2186,62 @@ func (s *StatusDisplayWidget) getState() *statusState {
 	}
 
 	newState := &statusState{
-		messages:    make([]string, 0),
-		timestamps:  make([]float64, 0),
+		entries:     make([]statusEntry, 0),
 		maxMessages: 100,
 	}
 	GlobalContext.stateMap[s.id] = newState
 	return newState
 }
 
+// AddMessage adds an informational message
 func (s *StatusDisplayWidget) AddMessage(message string) {
+	s.AddLevelMessage(StatusInfo, message)
+}
+
+// AddLevelMessage adds a message with the given severity
+func (s *StatusDisplayWidget) AddLevelMessage(level StatusLevel, message string) {
 	state := s.getState()
-	currentTime := imgui.Time()
+	entry := statusEntry{
+		message:  message,
+		level:    level,
+		time:     imgui.Time(),
+		wallTime: time.Now(),
+	}
 
-	state.messages = append(state.messages, message)
-	state.timestamps = append(state.timestamps, currentTime)
+	state.entries = append(state.entries, entry)
+	if len(state.entries) > state.maxMessages {
+		state.entries = state.entries[1:]
+	}
+
+	if logFile := state.logFile; logFile != nil {
+		if err := logFile.WriteLine(entry.format(true)); err != nil {
+			// Stop teeing before reporting, since the diagnostic is logged
+			// here too; TeeToFile starts it again
+			state.logFile = nil
+			logFile.Close()
+			ReportDiagnostic(fmt.Sprintf("status log stopped: %v", err))
+		}
+	}
+}
 
-	if len(state.messages) > state.maxMessages {
-		state.messages = state.messages[1:]
-		state.timestamps = state.timestamps[1:]
+// format renders an entry as a single line of text
+func (e statusEntry) format(wallClock bool) string {
+	if wallClock {
+		return fmt.Sprintf("[%s] %-5s %s", e.wallTime.Format("15:04:05"), e.level, e.message)
+	}
+	return fmt.Sprintf("[%.1fs] %s", imgui.Time()-e.time, e.message)
+}
+
+// levelColor derives the message color from the current theme's text color
+func levelColor(level StatusLevel) imgui.Vec4 {
+	text := *imgui.StyleColorVec4(imgui.ColText)
+	switch level {
+	case StatusWarn:
+		return Mix(text, RGB(255, 190, 0), 0.75)
+	case StatusError:
+		return Mix(text, RGB(255, 60, 60), 0.8)
+	default:
+		return text
 	}
 }
 
@@ -914,13 +2249,41 @@ func (s *StatusDisplayWidget) Build() {
 	state := s.getState()
 	currentTime := imgui.Time()
 
-	for i := len(state.messages) - 1; i >= 0; i-- {
-		age := currentTime - state.timestamps[i]
+	if s.filters {
+		for level := StatusInfo; level <= StatusError; level++ {
+			shown := !state.hidden[level]
+			if imgui.Checkbox(fmt.Sprintf("%s##%s_filter_%d", level, s.id, level), &shown) {
+				state.hidden[level] = !shown
+			}
+			imgui.SameLine()
+		}
+
+		if imgui.Button(fmt.Sprintf("Copy All##%s_copy", s.id)) {
+			lines := make([]string, 0, len(state.entries))
+			for _, entry := range state.entries {
+				lines = append(lines, entry.format(true))
+			}
+			imgui.SetClipboardText(strings.Join(lines, "\n"))
+		}
+	}
+
+	if s.height > 0 {
+		imgui.BeginChildStrV(s.id+"_messages", imgui.Vec2{Y: s.height}, imgui.ChildFlagsBorders, 0)
+		defer imgui.EndChild()
+	}
+
+	for i := len(state.entries) - 1; i >= 0; i-- {
+		entry := state.entries[i]
+		if state.hidden[entry.level] {
+			continue
+		}
 
-		if age < 10.0 {
-			timeStr := fmt.Sprintf("[%.1fs] %s", age, state.messages[i])
-			imgui.Text(timeStr)
+		age := currentTime - entry.time
+		if s.maxAge > 0 && age >= s.maxAge {
+			continue
 		}
+
+		imgui.TextColored(levelColor(entry.level), entry.format(s.timestamps))
 	}
 }
 
@@ -962,28 +2325,28 @@ func (s *StyleSetter) Build() {
 
 	// Push all style colors
 	for colorID, color := range s.colors {
-		imgui.PushStyleColorVec4(imgui.Col(colorID), color)
+		PushStyleColor(imgui.Col(colorID), color)
 	}
 
 	// Push all style variables
 	for varID, value := range s.vars {
-		imgui.PushStyleVarFloat(imgui.StyleVar(varID), value)
+		PushStyleVar(imgui.StyleVar(varID), value)
 	}
 
+	// Let per-kind defaults know which styles are overridden locally
+	GlobalContext.styleOverrides = append(GlobalContext.styleOverrides, s)
+	defer func() {
+		GlobalContext.styleOverrides = GlobalContext.styleOverrides[:len(GlobalContext.styleOverrides)-1]
+	}()
+
 	// Render child widgets with applied styles
 	for _, widget := range s.widgets {
-		if widget != nil {
-			widget.Build()
-		}
+		buildWidget(widget)
 	}
 
 	// Pop in reverse order (IMPORTANT!)
-	if varCount > 0 {
-		imgui.PopStyleVarV(varCount)
-	}
-	if colorCount > 0 {
-		imgui.PopStyleColorV(colorCount)
-	}
+	PopStyleVar(varCount)
+	PopStyleColor(colorCount)
 }
 
 // Theme represents a complete UI theme
@@ -1064,19 +2427,32 @@ func RGBA(r, g, b, a float32) imgui.Vec4 {
 	return imgui.Vec4{X: r / 255.0, Y: g / 255.0, Z: b / 255.0, W: a / 255.0}
 }
 
-func ColorFromHex(hex string) imgui.Vec4 {
-	if len(hex) > 0 && hex[0] == '#' {
-		hex = hex[1:]
+// ColorFromHex parses #RGB, #RGBA, #RRGGBB and #RRGGBBAA colors (the # is optional)
+func ColorFromHex(hex string) (imgui.Vec4, error) {
+	digits := strings.TrimPrefix(hex, "#")
+
+	// Expand the short forms by doubling every digit
+	if len(digits) == 3 || len(digits) == 4 {
+		expanded := make([]byte, 0, len(digits)*2)
+		for i := 0; i < len(digits); i++ {
+			expanded = append(expanded, digits[i], digits[i])
+		}
+		digits = string(expanded)
 	}
 
-	if len(hex) != 6 {
-		return imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1}
+	if len(digits) == 6 {
+		digits += "ff"
+	}
+	if len(digits) != 8 {
+		return imgui.Vec4{}, fmt.Errorf("invalid hex color %q: expected 3, 4, 6 or 8 digits", hex)
 	}
 
-	var r, g, b int
-	fmt.Sscanf(hex, "%02x%02x%02x", &r, &g, &b)
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return imgui.Vec4{}, fmt.Errorf("invalid hex color %q: %w", hex, err)
+	}
 
-	return RGB(float32(r), float32(g), float32(b))
+	return RGBA(float32(value>>24&0xff), float32(value>>16&0xff), float32(value>>8&0xff), float32(value&0xff)), nil
 }
 
 // FIXED: Working theme switching and styling demo
@@ -1240,12 +2616,26 @@ func loop() {
 }
 
 func main() {
+	// Forward to an already running demo instead of opening a second window
+	if primary, err := EnsureSingleInstance("gui-styling-demo"); err != nil {
+		fmt.Printf("single instance check failed: %v\n", err)
+	} else if !primary {
+		return
+	}
+
 	// Set initial theme
 	SetGlobalTheme(DarkTheme)
 
 	// Create master window
-	wnd := NewMasterWindow("Step 10: Complete Styling System", 900, 700)
+	wnd, err := NewMasterWindow("Step 10: Complete Styling System", 900, 700)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to open window: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Run the application
-	wnd.Run(loop)
+	if err := wnd.Run(loop); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+func TestColorFromHex(t *testing.T) {
+	tests := []struct {
+		hex     string
+		want    imgui.Vec4
+		wantErr bool
+	}{
+		{hex: "#fff", want: imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1}},
+		{hex: "#f00", want: imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1}},
+		{hex: "#f008", want: RGBA(0xff, 0, 0, 0x88)},
+		{hex: "#112233", want: RGBA(0x11, 0x22, 0x33, 0xff)},
+		{hex: "#11223344", want: RGBA(0x11, 0x22, 0x33, 0x44)},
+		{hex: "AABBCC", want: RGBA(0xaa, 0xbb, 0xcc, 0xff)},
+		{hex: "", wantErr: true},
+		{hex: "#", wantErr: true},
+		{hex: "#12345", wantErr: true},
+		{hex: "#ggg", wantErr: true},
+		{hex: "#1122334455", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.hex, func(t *testing.T) {
+			got, err := ColorFromHex(test.hex)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("ColorFromHex(%q) = %v, want an error", test.hex, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ColorFromHex(%q): %v", test.hex, err)
+			}
+			if got != test.want {
+				t.Errorf("ColorFromHex(%q) = %v, want %v", test.hex, got, test.want)
+			}
+		})
+	}
+}
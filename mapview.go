@@ -0,0 +1,369 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+const (
+	// mapTileSize is the pixel size of a slippy-map tile
+	mapTileSize = 256
+	mapMinZoom  = 0
+	mapMaxZoom  = 19
+)
+
+// mapTileClient fetches tiles; tile servers can be slow, but a stuck
+// request must not hold a tile in the loading state forever
+var mapTileClient = &http.Client{Timeout: 20 * time.Second}
+
+// LatLng is a geographic position in degrees
+type LatLng struct {
+	Lat float64
+	Lng float64
+}
+
+// mercator projects a position to Web Mercator coordinates in [0, 1]
+func (l LatLng) mercator() (x, y float64) {
+	lat := math.Max(-85.05112878, math.Min(85.05112878, l.Lat)) * math.Pi / 180
+	x = (l.Lng + 180) / 360
+	y = (1 - math.Log(math.Tan(lat)+1/math.Cos(lat))/math.Pi) / 2
+	return x, y
+}
+
+// latLngFromMercator is the inverse of LatLng.mercator
+func latLngFromMercator(x, y float64) LatLng {
+	lat := math.Atan(math.Sinh(math.Pi * (1 - 2*y)))
+	return LatLng{Lat: lat * 180 / math.Pi, Lng: x*360 - 180}
+}
+
+// MapMarker is a point drawn on a MapView
+type MapMarker struct {
+	Position LatLng
+	Label    string // shown as a tooltip while hovered
+	Color    imgui.Vec4
+	OnClick  func()
+}
+
+// mapPolyline is a path drawn on a MapView
+type mapPolyline struct {
+	points    []LatLng
+	color     imgui.Vec4
+	thickness float32
+}
+
+// mapViewState holds the camera, in Mercator coordinates, and the tiles
+type mapViewState struct {
+	x, y        float64
+	zoom        int
+	initialized bool
+	dragged     bool
+	cache       *TextureCache
+	ownsCache   bool
+}
+
+func (s *mapViewState) Dispose() {
+	if s.ownsCache && s.cache != nil {
+		s.cache.Release()
+		s.cache = nil
+	}
+}
+
+// MapViewWidget shows slippy-map tiles with pan and zoom, markers and
+// polylines
+type MapViewWidget struct {
+	id          string
+	urlTemplate string
+	userAgent   string
+	attribution string
+	center      LatLng
+	zoom        int
+	width       float32
+	height      float32
+	markers     []MapMarker
+	polylines   []mapPolyline
+	cache       *TextureCache
+}
+
+// MapView creates a map fetching tiles from urlTemplate, in which {z}, {x}
+// and {y} are replaced by the tile coordinates and {s} by a server
+// subdomain (a, b or c), e.g. "https://tile.openstreetmap.org/{z}/{x}/{y}.png"
+func MapView(id, urlTemplate string) *MapViewWidget {
+	return &MapViewWidget{
+		id:          fmt.Sprintf("##mapview_%s", id),
+		urlTemplate: urlTemplate,
+		userAgent:   "techarmour-gui",
+		zoom:        2,
+	}
+}
+
+// Center sets the initial position and zoom level; afterwards the user
+// pans and zooms freely (builder pattern)
+func (m *MapViewWidget) Center(center LatLng, zoom int) *MapViewWidget {
+	m.center = center
+	m.zoom = zoom
+	return m
+}
+
+// Size sets the map size; zero fills the available space (builder pattern)
+func (m *MapViewWidget) Size(width, height float32) *MapViewWidget {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// UserAgent sets the User-Agent sent to the tile server; public servers
+// require one identifying the app (builder pattern)
+func (m *MapViewWidget) UserAgent(userAgent string) *MapViewWidget {
+	m.userAgent = userAgent
+	return m
+}
+
+// Attribution sets the text drawn in the bottom-right corner, as most tile
+// licenses require (builder pattern)
+func (m *MapViewWidget) Attribution(attribution string) *MapViewWidget {
+	m.attribution = attribution
+	return m
+}
+
+// Markers sets the points drawn on the map (builder pattern)
+func (m *MapViewWidget) Markers(markers ...MapMarker) *MapViewWidget {
+	m.markers = markers
+	return m
+}
+
+// Polyline adds a path through points (builder pattern)
+func (m *MapViewWidget) Polyline(points []LatLng, color imgui.Vec4, thickness float32) *MapViewWidget {
+	m.polylines = append(m.polylines, mapPolyline{points: points, color: color, thickness: thickness})
+	return m
+}
+
+// Cache shares a texture cache between maps; by default each map has its
+// own 64 MiB cache (builder pattern)
+func (m *MapViewWidget) Cache(cache *TextureCache) *MapViewWidget {
+	m.cache = cache
+	return m
+}
+
+// Position returns the position at the center of the map and the zoom level
+func (m *MapViewWidget) Position() (LatLng, int) {
+	state := m.getState()
+	return latLngFromMercator(state.x, state.y), state.zoom
+}
+
+func (m *MapViewWidget) getState() *mapViewState {
+	if existingState, exists := GlobalContext.stateMap[m.id]; exists {
+		if state, ok := existingState.(*mapViewState); ok {
+			return state
+		}
+	}
+
+	newState := &mapViewState{}
+	GlobalContext.stateMap[m.id] = newState
+	return newState
+}
+
+// tileURL fills in the URL template for a tile
+func (m *MapViewWidget) tileURL(x, y, zoom int) string {
+	return strings.NewReplacer(
+		"{z}", strconv.Itoa(zoom),
+		"{x}", strconv.Itoa(x),
+		"{y}", strconv.Itoa(y),
+		"{s}", string(rune('a'+(x+y)%3)),
+	).Replace(m.urlTemplate)
+}
+
+// loadTile downloads and decodes one tile
+func (m *MapViewWidget) loadTile(url string) (image.Image, error) {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("User-Agent", m.userAgent)
+
+	response, err := mapTileClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching tile: %s", response.Status)
+	}
+
+	img, _, err := image.Decode(response.Body)
+	return img, err
+}
+
+func (m *MapViewWidget) Build() {
+	state := m.getState()
+	if !state.initialized {
+		state.x, state.y = m.center.mercator()
+		state.zoom = max(mapMinZoom, min(mapMaxZoom, m.zoom))
+		state.initialized = true
+	}
+	switch {
+	case m.cache != nil && state.cache != m.cache:
+		state.Dispose()
+		state.cache, state.ownsCache = m.cache, false
+	case state.cache == nil:
+		state.cache, state.ownsCache = NewTextureCache(64<<20), true
+	}
+
+	size := imgui.ContentRegionAvail()
+	if m.width > 0 {
+		size.X = m.width
+	}
+	if m.height > 0 {
+		size.Y = m.height
+	}
+	if size.X <= 0 || size.Y <= 0 {
+		return
+	}
+
+	origin := imgui.CursorScreenPos()
+	clicked := imgui.InvisibleButton(m.id, size)
+	hovered := imgui.IsItemHovered()
+	m.handleInput(state, origin, size)
+
+	scale := float64(mapTileSize) * math.Exp2(float64(state.zoom))
+	center := origin.Add(size.Mul(0.5))
+	project := func(position LatLng) imgui.Vec2 {
+		x, y := position.mercator()
+		return imgui.Vec2{
+			X: center.X + float32((x-state.x)*scale),
+			Y: center.Y + float32((y-state.y)*scale),
+		}
+	}
+
+	drawList := imgui.WindowDrawList()
+	drawList.PushClipRectV(origin, origin.Add(size), true)
+	defer drawList.PopClipRect()
+	drawList.AddRectFilled(origin, origin.Add(size), imgui.ColorU32Col(imgui.ColFrameBg))
+
+	m.drawTiles(drawList, state, center, size, scale)
+
+	for _, line := range m.polylines {
+		color := imgui.ColorU32Vec4(line.color)
+		for i := 1; i < len(line.points); i++ {
+			drawList.AddLineV(project(line.points[i-1]), project(line.points[i]), color, line.thickness)
+		}
+	}
+
+	const markerRadius = 6
+	mouse := imgui.MousePos()
+	var hoveredMarker *MapMarker
+	for i := range m.markers {
+		marker := &m.markers[i]
+		pos := project(marker.Position)
+		color := marker.Color
+		if color.W == 0 {
+			color = imgui.Vec4{X: 0.9, Y: 0.25, Z: 0.25, W: 1}
+		}
+		drawList.AddCircleFilled(pos, markerRadius+1.5, imgui.ColorU32Vec4(imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1}))
+		drawList.AddCircleFilled(pos, markerRadius, imgui.ColorU32Vec4(color))
+
+		offset := mouse.Sub(pos)
+		if hovered && offset.X*offset.X+offset.Y*offset.Y <= (markerRadius+2)*(markerRadius+2) {
+			hoveredMarker = marker
+		}
+	}
+
+	if m.attribution != "" {
+		textSize := imgui.CalcTextSize(m.attribution)
+		textPos := origin.Add(size).Sub(textSize).Sub(imgui.Vec2{X: 4, Y: 2})
+		drawList.AddRectFilled(textPos.Sub(imgui.Vec2{X: 4, Y: 2}), origin.Add(size),
+			imgui.ColorU32Vec4(imgui.Vec4{X: 1, Y: 1, Z: 1, W: 0.7}))
+		drawList.AddTextVec2(textPos, imgui.ColorU32Vec4(imgui.Vec4{X: 0.1, Y: 0.1, Z: 0.1, W: 1}), m.attribution)
+	}
+
+	if hoveredMarker != nil {
+		if hoveredMarker.Label != "" {
+			imgui.SetTooltip(hoveredMarker.Label)
+		}
+		if hoveredMarker.OnClick != nil {
+			SetCursor(CursorHand)
+			if clicked && !state.dragged {
+				hoveredMarker.OnClick()
+			}
+		}
+	}
+}
+
+// handleInput pans while dragging and zooms around the mouse on the wheel
+func (m *MapViewWidget) handleInput(state *mapViewState, origin, size imgui.Vec2) {
+	scale := float64(mapTileSize) * math.Exp2(float64(state.zoom))
+
+	if imgui.IsItemActivated() {
+		state.dragged = false
+	}
+	if imgui.IsItemActive() {
+		delta := imgui.CurrentIO().MouseDelta()
+		if delta.X != 0 || delta.Y != 0 {
+			state.dragged = true
+			state.x -= float64(delta.X) / scale
+			state.y -= float64(delta.Y) / scale
+		}
+	}
+
+	if wheel := imgui.CurrentIO().MouseWheel(); wheel != 0 && imgui.IsItemHovered() {
+		zoom := state.zoom + 1
+		if wheel < 0 {
+			zoom = state.zoom - 1
+		}
+		zoom = max(mapMinZoom, min(mapMaxZoom, zoom))
+		if zoom != state.zoom {
+			// Keep the point under the mouse in place
+			offset := imgui.MousePos().Sub(origin.Add(size.Mul(0.5)))
+			mouseX := state.x + float64(offset.X)/scale
+			mouseY := state.y + float64(offset.Y)/scale
+			newScale := float64(mapTileSize) * math.Exp2(float64(zoom))
+			state.x = mouseX - float64(offset.X)/newScale
+			state.y = mouseY - float64(offset.Y)/newScale
+			state.zoom = zoom
+		}
+	}
+
+	// Wrap around horizontally and stop at the poles
+	state.x -= math.Floor(state.x)
+	state.y = math.Max(0, math.Min(1, state.y))
+}
+
+// drawTiles draws the tiles covering the view, requesting missing ones
+func (m *MapViewWidget) drawTiles(drawList *imgui.DrawList, state *mapViewState, center, size imgui.Vec2, scale float64) {
+	tiles := 1 << state.zoom
+	left := state.x*scale - float64(size.X)/2
+	top := state.y*scale - float64(size.Y)/2
+	firstX, lastX := int(math.Floor(left/mapTileSize)), int(math.Floor((left+float64(size.X))/mapTileSize))
+	firstY, lastY := int(math.Floor(top/mapTileSize)), int(math.Floor((top+float64(size.Y))/mapTileSize))
+
+	placeholder := imgui.ColorU32Col(imgui.ColFrameBgHovered)
+	for ty := max(firstY, 0); ty <= min(lastY, tiles-1); ty++ {
+		for tx := firstX; tx <= lastX; tx++ {
+			tileMin := imgui.Vec2{
+				X: center.X + float32(float64(tx*mapTileSize)-state.x*scale),
+				Y: center.Y + float32(float64(ty*mapTileSize)-state.y*scale),
+			}
+			tileMax := tileMin.Add(imgui.Vec2{X: mapTileSize, Y: mapTileSize})
+
+			// Tiles repeat east and west of the antimeridian
+			wrapped := ((tx % tiles) + tiles) % tiles
+			url := m.tileURL(wrapped, ty, state.zoom)
+			texture := state.cache.Get(url, func() (image.Image, error) {
+				return m.loadTile(url)
+			})
+			if texture == nil {
+				drawList.AddRect(tileMin.Add(imgui.Vec2{X: 1, Y: 1}), tileMax, placeholder)
+				continue
+			}
+			drawList.AddImage(texture.ID, tileMin, tileMax)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// MenuItemWidget is a command in a menu, optionally with a check mark and
+// a keyboard shortcut
+type MenuItemWidget struct {
+	label    string
+	shortcut string
+	chord    *KeyChord
+	checked  *bool
+	disabled bool
+	onClick  func()
+}
+
+// MenuItem creates a menu command
+func MenuItem(label string) *MenuItemWidget {
+	return &MenuItemWidget{label: label}
+}
+
+// Shortcut binds a chord like "Ctrl+S" to OnClick and shows it in the menu.
+// The chord works while the menu is closed, as long as the menu bar is
+// built (builder pattern)
+func (m *MenuItemWidget) Shortcut(shortcut string) *MenuItemWidget {
+	chord, err := ParseKeyChord(shortcut)
+	if err != nil {
+		LogWarning(err.Error())
+		return m
+	}
+	m.shortcut = shortcut
+	m.chord = &chord
+	return m
+}
+
+// Checked shows a check mark while *checked is true; choosing the item
+// toggles it before OnClick runs (builder pattern)
+func (m *MenuItemWidget) Checked(checked *bool) *MenuItemWidget {
+	m.checked = checked
+	return m
+}
+
+// Enabled greys the item out when false (builder pattern)
+func (m *MenuItemWidget) Enabled(enabled bool) *MenuItemWidget {
+	m.disabled = !enabled
+	return m
+}
+
+// OnClick sets the callback invoked when the item is chosen (builder pattern)
+func (m *MenuItemWidget) OnClick(onClick func()) *MenuItemWidget {
+	m.onClick = onClick
+	return m
+}
+
+// activate performs the item's action, from a click or its shortcut
+func (m *MenuItemWidget) activate() {
+	if m.checked != nil {
+		*m.checked = !*m.checked
+	}
+	if m.onClick != nil {
+		m.onClick()
+	}
+}
+
+// registerShortcut keeps the item's chord in the shortcut registry
+func (m *MenuItemWidget) registerShortcut() {
+	if m.chord != nil && !m.disabled {
+		RegisterShortcut(*m.chord, m.activate)
+	}
+}
+
+func (m *MenuItemWidget) Build() {
+	checked := m.checked != nil && *m.checked
+	if imgui.MenuItemBoolV(m.label, m.shortcut, checked, !m.disabled) {
+		m.activate()
+	}
+}
+
+// menuSeparatorWidget is a line between groups of menu items
+type menuSeparatorWidget struct{}
+
+// MenuSeparator creates a line between groups of menu items
+func MenuSeparator() Widget {
+	return &menuSeparatorWidget{}
+}
+
+func (m *menuSeparatorWidget) Build() {
+	imgui.Separator()
+}
+
+// MenuWidget is a drop-down menu in a menu bar, or a submenu
+type MenuWidget struct {
+	label    string
+	disabled bool
+	widgets  []Widget
+}
+
+// Menu creates a menu with the given title
+func Menu(label string) *MenuWidget {
+	return &MenuWidget{label: label}
+}
+
+// Enabled greys the menu out when false (builder pattern)
+func (m *MenuWidget) Enabled(enabled bool) *MenuWidget {
+	m.disabled = !enabled
+	return m
+}
+
+// Layout sets the menu's items: MenuItem, Menu, MenuSeparator or any other
+// widget (builder pattern)
+func (m *MenuWidget) Layout(widgets ...Widget) *MenuWidget {
+	m.widgets = widgets
+	return m
+}
+
+// registerShortcuts registers the chords of every item, open or not
+func (m *MenuWidget) registerShortcuts() {
+	if m.disabled {
+		return
+	}
+	registerMenuShortcuts(m.widgets)
+}
+
+func (m *MenuWidget) Build() {
+	if !imgui.BeginMenuV(m.label, !m.disabled) {
+		return
+	}
+	for _, widget := range m.widgets {
+		buildWidget(widget)
+	}
+	imgui.EndMenu()
+}
+
+// registerMenuShortcuts registers the chords of items and nested menus
+func registerMenuShortcuts(widgets []Widget) {
+	for _, widget := range widgets {
+		switch item := widget.(type) {
+		case *MenuItemWidget:
+			item.registerShortcut()
+		case *MenuWidget:
+			item.registerShortcuts()
+		}
+	}
+}
+
+// MenuBarWidget is the menu bar of the window it is placed in. Put it first
+// in a SingleWindow's layout; the window then reserves room for it.
+type MenuBarWidget struct {
+	menus []*MenuWidget
+}
+
+// MenuBar creates a window menu bar
+func MenuBar(menus ...*MenuWidget) *MenuBarWidget {
+	return &MenuBarWidget{menus: menus}
+}
+
+func (m *MenuBarWidget) Build() {
+	for _, menu := range m.menus {
+		menu.registerShortcuts()
+	}
+	if !imgui.BeginMenuBar() {
+		return
+	}
+	for _, menu := range m.menus {
+		buildWidget(menu)
+	}
+	imgui.EndMenuBar()
+}
+
+// MainMenuBarWidget is the menu bar along the top of the master window.
+// Build it before SingleWindow so the window fits below it.
+type MainMenuBarWidget struct {
+	menus []*MenuWidget
+}
+
+// MainMenuBar creates the application menu bar
+func MainMenuBar(menus ...*MenuWidget) *MainMenuBarWidget {
+	return &MainMenuBarWidget{menus: menus}
+}
+
+func (m *MainMenuBarWidget) Build() {
+	for _, menu := range m.menus {
+		menu.registerShortcuts()
+	}
+	if !imgui.BeginMainMenuBar() {
+		return
+	}
+	for _, menu := range m.menus {
+		buildWidget(menu)
+	}
+	imgui.EndMainMenuBar()
+}
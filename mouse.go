@@ -0,0 +1,105 @@
+package main
+
+import (
+	"github.com/AllenDang/cimgui-go/backend/glfwbackend"
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// MousePos returns the mouse position in screen coordinates
+func MousePos() imgui.Vec2 {
+	return imgui.CurrentIO().MousePos()
+}
+
+// MouseDelta returns how far the mouse moved since the last frame
+func MouseDelta() imgui.Vec2 {
+	return imgui.CurrentIO().MouseDelta()
+}
+
+// MouseWheel returns this frame's horizontal and vertical wheel movement
+func MouseWheel() (x, y float32) {
+	io := imgui.CurrentIO()
+	return io.MouseWheelH(), io.MouseWheel()
+}
+
+// mouseHandlers holds the window-level mouse callbacks
+type mouseHandlers struct {
+	onButton []func(button imgui.MouseButton, pressed bool)
+	onMove   []func(pos, delta imgui.Vec2)
+	onWheel  []func(x, y float32)
+}
+
+// SetRelativeMouse hides and locks the cursor so only movement is reported,
+// for camera controls in embedded viewports. Raw motion is used when the
+// platform supports it.
+func (w *MasterWindow) SetRelativeMouse(enabled bool) {
+	if enabled {
+		w.backend.SetInputMode(glfwbackend.GLFWInputModeCursor, glfwbackend.GLFWCursorDisabled)
+		w.backend.SetInputMode(glfwbackend.GLFWInputModeRawMouseMotion, 1)
+	} else {
+		w.backend.SetInputMode(glfwbackend.GLFWInputModeRawMouseMotion, 0)
+		w.backend.SetInputMode(glfwbackend.GLFWInputModeCursor, glfwbackend.GLFWCursorNormal)
+	}
+	w.relativeMouse = enabled
+}
+
+// IsRelativeMouse reports whether relative mouse mode is on
+func (w *MasterWindow) IsRelativeMouse() bool {
+	return w.relativeMouse
+}
+
+// OnMouseButton registers a callback for any mouse button press or release,
+// regardless of which widget is under the cursor
+func (w *MasterWindow) OnMouseButton(callback func(button imgui.MouseButton, pressed bool)) *MasterWindow {
+	handlers := w.mouseHandlers()
+	handlers.onButton = append(handlers.onButton, callback)
+	return w
+}
+
+// OnMouseMove registers a callback for mouse movement anywhere in the window
+func (w *MasterWindow) OnMouseMove(callback func(pos, delta imgui.Vec2)) *MasterWindow {
+	handlers := w.mouseHandlers()
+	handlers.onMove = append(handlers.onMove, callback)
+	return w
+}
+
+// OnMouseWheel registers a callback for wheel movement anywhere in the window
+func (w *MasterWindow) OnMouseWheel(callback func(x, y float32)) *MasterWindow {
+	handlers := w.mouseHandlers()
+	handlers.onWheel = append(handlers.onWheel, callback)
+	return w
+}
+
+// mouseHandlers returns the window's handlers, installing them on first use
+func (w *MasterWindow) mouseHandlers() *mouseHandlers {
+	if w.mouse == nil {
+		w.mouse = &mouseHandlers{}
+		w.BeforeFrame(w.mouse.dispatch)
+	}
+	return w.mouse
+}
+
+// dispatch reports this frame's mouse events to the callbacks
+func (m *mouseHandlers) dispatch() {
+	for _, button := range []imgui.MouseButton{imgui.MouseButtonLeft, imgui.MouseButtonRight, imgui.MouseButtonMiddle} {
+		pressed := imgui.IsMouseClickedBool(button)
+		if !pressed && !imgui.IsMouseReleased(button) {
+			continue
+		}
+		for _, callback := range m.onButton {
+			callback(button, pressed)
+		}
+	}
+
+	if delta := MouseDelta(); delta.X != 0 || delta.Y != 0 {
+		pos := MousePos()
+		for _, callback := range m.onMove {
+			callback(pos, delta)
+		}
+	}
+
+	if x, y := MouseWheel(); x != 0 || y != 0 {
+		for _, callback := range m.onWheel {
+			callback(x, y)
+		}
+	}
+}
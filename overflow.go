@@ -0,0 +1,106 @@
+package main
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Overflow selects what a container does with content larger than itself
+type Overflow int
+
+const (
+	OverflowVisible Overflow = iota // content spills over whatever follows
+	OverflowHidden                  // content is cut off at the edges
+	OverflowScroll                  // content scrolls
+)
+
+// buildOverflow builds widgets in an area of the given size, clipping or
+// scrolling them as overflow says. Zero size fills the available space.
+func buildOverflow(id string, overflow Overflow, size imgui.Vec2, widgets []Widget) {
+	if overflow == OverflowVisible {
+		for _, widget := range widgets {
+			buildWidget(widget)
+		}
+		return
+	}
+
+	// A child window clips its content and can scroll it
+	var flags imgui.WindowFlags
+	if overflow == OverflowHidden {
+		flags = imgui.WindowFlagsNoScrollbar | imgui.WindowFlagsNoScrollWithMouse
+	}
+	if imgui.BeginChildStrV(id, size, imgui.ChildFlagsNone, flags) {
+		for _, widget := range widgets {
+			buildWidget(widget)
+		}
+	}
+	imgui.EndChild()
+}
+
+// ID sets the column's ID, needed to keep the scroll position of sibling
+// columns with an Overflow apart (builder pattern)
+func (c *ColumnWidget) ID(id string) *ColumnWidget {
+	c.id = "##column_" + id
+	return c
+}
+
+// Size sets the area the column's Overflow applies to; zero fills the
+// available space (builder pattern)
+func (c *ColumnWidget) Size(width, height float32) *ColumnWidget {
+	c.width = width
+	c.height = height
+	return c
+}
+
+// Overflow sets what happens to content beyond the column's Size (builder pattern)
+func (c *ColumnWidget) Overflow(overflow Overflow) *ColumnWidget {
+	c.overflow = overflow
+	return c
+}
+
+// Clip cuts off content beyond the column's Size (builder pattern)
+func (c *ColumnWidget) Clip(clip bool) *ColumnWidget {
+	c.overflow = OverflowVisible
+	if clip {
+		c.overflow = OverflowHidden
+	}
+	return c
+}
+
+// Height limits the box's contents to height pixels, applying its
+// Overflow to anything taller (builder pattern)
+func (g *GroupBoxWidget) Height(height float32) *GroupBoxWidget {
+	g.height = height
+	return g
+}
+
+// Overflow sets what happens to content taller than the box's Height (builder pattern)
+func (g *GroupBoxWidget) Overflow(overflow Overflow) *GroupBoxWidget {
+	g.overflow = overflow
+	return g
+}
+
+// Clip cuts off content taller than the box's Height (builder pattern)
+func (g *GroupBoxWidget) Clip(clip bool) *GroupBoxWidget {
+	g.overflow = OverflowVisible
+	if clip {
+		g.overflow = OverflowHidden
+	}
+	return g
+}
+
+// Overflow sets what happens to content larger than the region: it scrolls
+// by default, is cut off when hidden, and grows the region to fit when
+// visible (builder pattern)
+func (c *ChildWidget) Overflow(overflow Overflow) *ChildWidget {
+	c.overflow = overflow
+	return c
+}
+
+// Clip cuts off content larger than the region instead of scrolling it (builder pattern)
+func (c *ChildWidget) Clip(clip bool) *ChildWidget {
+	c.overflow = OverflowScroll
+	if clip {
+		c.overflow = OverflowHidden
+	}
+	return c
+}
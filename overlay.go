@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// OverlayEntry is a widget rendered above all windows every frame until
+// it is removed
+type OverlayEntry struct {
+	id          string
+	widget      Widget
+	order       int
+	interactive bool
+	dim         *imgui.Vec4
+	anchor      *Anchor
+	size        imgui.Vec2
+}
+
+// overlays are the entries of the overlay layer, drawn in order
+var overlays []*OverlayEntry
+
+// AddOverlay registers widget on the overlay layer under id, replacing any
+// entry with the same id. Overlays ignore the mouse unless Interactive.
+func AddOverlay(id string, widget Widget) *OverlayEntry {
+	RemoveOverlay(id)
+	entry := &OverlayEntry{id: id, widget: widget}
+	overlays = append(overlays, entry)
+	return entry
+}
+
+// RemoveOverlay removes the entry registered under id
+func RemoveOverlay(id string) {
+	for i, entry := range overlays {
+		if entry.id == id {
+			overlays = append(overlays[:i], overlays[i+1:]...)
+			return
+		}
+	}
+}
+
+// HasOverlay reports whether an entry is registered under id
+func HasOverlay(id string) bool {
+	for _, entry := range overlays {
+		if entry.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Order sets the stacking order; higher orders draw on top (builder pattern)
+func (o *OverlayEntry) Order(order int) *OverlayEntry {
+	o.order = order
+	return o
+}
+
+// Interactive lets the entry receive mouse and keyboard input. A
+// full-screen interactive entry blocks input to the windows below, which
+// suits modal dimming; anchor it to keep the rest usable (builder pattern)
+func (o *OverlayEntry) Interactive(interactive bool) *OverlayEntry {
+	o.interactive = interactive
+	return o
+}
+
+// Dim fills the screen with color behind the entry (builder pattern)
+func (o *OverlayEntry) Dim(color imgui.Vec4) *OverlayEntry {
+	o.dim = &color
+	return o
+}
+
+// Anchor sizes the entry to its content and positions it with anchor
+// instead of covering the whole screen (builder pattern)
+func (o *OverlayEntry) Anchor(anchor Anchor) *OverlayEntry {
+	o.anchor = &anchor
+	return o
+}
+
+// drawOverlays renders the overlay layer; runs after the user's loop so it
+// ends up above every window
+func drawOverlays() {
+	if len(overlays) == 0 {
+		return
+	}
+
+	// Entries may add or remove overlays while building
+	entries := append([]*OverlayEntry(nil), overlays...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].order < entries[j].order
+	})
+
+	viewport := imgui.MainViewport()
+	for _, entry := range entries {
+		flags := imgui.WindowFlagsNoDecoration | imgui.WindowFlagsNoBackground |
+			imgui.WindowFlagsNoSavedSettings | imgui.WindowFlagsNoFocusOnAppearing
+		if !entry.interactive {
+			flags |= imgui.WindowFlagsNoInputs
+		}
+
+		if entry.anchor != nil {
+			flags |= imgui.WindowFlagsAlwaysAutoResize
+			entry.anchor.place(viewport.Pos(), viewport.Pos().Add(viewport.Size()), entry.size)
+		} else {
+			imgui.SetNextWindowPos(viewport.Pos())
+			imgui.SetNextWindowSize(viewport.Size())
+		}
+
+		if imgui.BeginV(fmt.Sprintf("##overlay_%s", entry.id), nil, flags) {
+			imgui.InternalBringWindowToDisplayFront(imgui.InternalCurrentWindow())
+			if entry.dim != nil {
+				// The window's own draw list keeps the dimming directly
+				// below its content but above every other window
+				screenMax := viewport.Pos().Add(viewport.Size())
+				drawList := imgui.WindowDrawList()
+				drawList.PushClipRectV(viewport.Pos(), screenMax, false)
+				drawList.AddRectFilled(viewport.Pos(), screenMax, imgui.ColorU32Vec4(*entry.dim))
+				drawList.PopClipRect()
+			}
+			buildWidget(entry.widget)
+			entry.size = imgui.WindowSize()
+		}
+		imgui.End()
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Poller fetches an endpoint on a schedule in the background. Results and
+// errors are delivered on the UI thread, so callbacks can update app state
+// without locking.
+type Poller struct {
+	url        string
+	interval   time.Duration
+	timeout    time.Duration
+	headers    http.Header
+	decode     func(body io.Reader) (func(), error)
+	stop       chan struct{}
+	lastErr    error
+	lastUpdate time.Time
+}
+
+// PollJSON fetches url every interval and decodes the body into a fresh T,
+// passed to onResult on the UI thread
+func PollJSON[T any](url string, interval time.Duration, onResult func(T)) *Poller {
+	return newPoller(url, interval, func(body io.Reader) (func(), error) {
+		var result T
+		if err := json.NewDecoder(body).Decode(&result); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", url, err)
+		}
+		return func() { onResult(result) }, nil
+	})
+}
+
+// PollMetrics fetches a Prometheus text-format endpoint every interval and
+// passes the parsed samples to onResult on the UI thread
+func PollMetrics(url string, interval time.Duration, onResult func(Metrics)) *Poller {
+	return newPoller(url, interval, func(body io.Reader) (func(), error) {
+		metrics, err := ParseMetrics(body)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", url, err)
+		}
+		return func() { onResult(metrics) }, nil
+	})
+}
+
+func newPoller(url string, interval time.Duration, decode func(body io.Reader) (func(), error)) *Poller {
+	p := &Poller{
+		url:      url,
+		interval: interval,
+		timeout:  10 * time.Second,
+		headers:  http.Header{},
+		decode:   decode,
+		stop:     make(chan struct{}),
+	}
+	// Start on the next frame so the builders below apply to the first poll
+	go func() {
+		select {
+		case uiQueue <- func() { go p.run() }:
+		case <-p.stop:
+		}
+	}()
+	return p
+}
+
+// Timeout sets how long one request may take (builder pattern)
+func (p *Poller) Timeout(timeout time.Duration) *Poller {
+	p.timeout = timeout
+	return p
+}
+
+// Header adds a request header, e.g. for authorization (builder pattern)
+func (p *Poller) Header(key, value string) *Poller {
+	p.headers.Add(key, value)
+	return p
+}
+
+// Stop ends polling; a request in flight is cancelled
+func (p *Poller) Stop() {
+	select {
+	case <-p.stop:
+	default:
+		close(p.stop)
+	}
+}
+
+// Err returns the error of the last poll, or nil if it succeeded
+func (p *Poller) Err() error {
+	return p.lastErr
+}
+
+// LastUpdate returns when a poll last succeeded; zero before the first
+func (p *Poller) LastUpdate() time.Time {
+	return p.lastUpdate
+}
+
+// run polls until stopped, immediately and then every interval
+func (p *Poller) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-p.stop
+		cancel()
+	}()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		apply, err := p.fetch(ctx)
+		result := func() {
+			p.lastErr = err
+			if err == nil {
+				p.lastUpdate = time.Now()
+				apply()
+			}
+		}
+
+		select {
+		case uiQueue <- result:
+		case <-p.stop:
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// fetch performs one request and decodes the response
+func (p *Poller) fetch(ctx context.Context) (func(), error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header = p.headers.Clone()
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("polling %s: %s", p.url, response.Status)
+	}
+	return p.decode(response.Body)
+}
+
+// Metrics are the samples of a Prometheus scrape, keyed by series as
+// written in the exposition, e.g. `http_requests_total{code="200"}`
+type Metrics map[string]float64
+
+// Get returns the value of one series
+func (m Metrics) Get(series string) (float64, bool) {
+	value, ok := m[series]
+	return value, ok
+}
+
+// Sum adds up every series of the metric name, whatever their labels
+func (m Metrics) Sum(name string) float64 {
+	sum := 0.0
+	for series, value := range m {
+		if metricName(series) == name {
+			sum += value
+		}
+	}
+	return sum
+}
+
+// metricName strips the labels from a series
+func metricName(series string) string {
+	if i := strings.IndexByte(series, '{'); i >= 0 {
+		return series[:i]
+	}
+	return series
+}
+
+// labelsEnd returns the index of the brace closing the label set opened at
+// open, skipping braces inside quoted label values, or -1 if it is missing
+func labelsEnd(text string, open int) int {
+	quoted := false
+	for i := open + 1; i < len(text); i++ {
+		switch {
+		case quoted && text[i] == '\\':
+			i++ // the escaped character can't end the value
+		case text[i] == '"':
+			quoted = !quoted
+		case !quoted && text[i] == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+// ParseMetrics reads the Prometheus text exposition format. Comments and
+// timestamps are ignored.
+func ParseMetrics(r io.Reader) (Metrics, error) {
+	metrics := Metrics{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || text[0] == '#' {
+			continue
+		}
+
+		// The labels may contain spaces, so the value follows the closing brace
+		rest := text
+		series := ""
+		if open := strings.IndexByte(text, '{'); open >= 0 {
+			end := labelsEnd(text, open)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated labels", line)
+			}
+			series, rest = text[:end+1], text[end+1:]
+		} else if i := strings.IndexAny(text, " \t"); i >= 0 {
+			series, rest = text[:i], text[i:]
+		}
+
+		fields := strings.Fields(rest)
+		if series == "" || len(fields) == 0 {
+			return nil, fmt.Errorf("line %d: missing value", line)
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		metrics[series] = value
+	}
+	return metrics, scanner.Err()
+}
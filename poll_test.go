@@ -0,0 +1,89 @@
+package main
+
+import (
+	"maps"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Metrics
+		wantErr bool
+	}{
+		{
+			name:  "plain series",
+			input: "up 1\nprocess_cpu_seconds_total 12.5\n",
+			want:  Metrics{"up": 1, "process_cpu_seconds_total": 12.5},
+		},
+		{
+			name: "comments and blank lines",
+			input: "# HELP up Whether the target is up.\n" +
+				"# TYPE up gauge\n" +
+				"\n" +
+				"up 1\n",
+			want: Metrics{"up": 1},
+		},
+		{
+			name:  "labels with spaces",
+			input: `http_requests_total{path="/a b", code="200"} 3` + "\n",
+			want:  Metrics{`http_requests_total{path="/a b", code="200"}`: 3},
+		},
+		{
+			name:  "brace in label value",
+			input: `http_requests_total{path="/a}b"} 4` + "\n",
+			want:  Metrics{`http_requests_total{path="/a}b"}`: 4},
+		},
+		{
+			name:  "escaped quote in label value",
+			input: `http_requests_total{path="say \"}\" x"} 5` + "\n",
+			want:  Metrics{`http_requests_total{path="say \"}\" x"}`: 5},
+		},
+		{
+			name:  "timestamp ignored",
+			input: "up 1 1700000000000\n",
+			want:  Metrics{"up": 1},
+		},
+		{
+			name:  "infinity",
+			input: "a +Inf\n",
+			want:  Metrics{"a": math.Inf(1)},
+		},
+		{name: "missing value", input: "up\n", wantErr: true},
+		{name: "missing value after labels", input: `up{job="a"}` + "\n", wantErr: true},
+		{name: "bad value", input: "up yes\n", wantErr: true},
+		{name: "unterminated labels", input: `up{job="a} 1` + "\n", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseMetrics(strings.NewReader(test.input))
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("ParseMetrics() = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMetrics(): %v", err)
+			}
+			if !maps.Equal(got, test.want) {
+				t.Errorf("ParseMetrics() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMetricsSum(t *testing.T) {
+	metrics := Metrics{
+		`requests{code="200"}`: 5,
+		`requests{code="500"}`: 2,
+		"requests_total":       100,
+	}
+	if got := metrics.Sum("requests"); got != 7 {
+		t.Errorf("Sum(requests) = %v, want 7", got)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// OpenPopup opens the Popup or PopupModal with the given name when it is
+// next built. Safe to call from callbacks outside the popup's ID scope.
+func (c *Context) OpenPopup(name string) {
+	if !slices.Contains(c.pendingPopups, name) {
+		c.pendingPopups = append(c.pendingPopups, name)
+	}
+}
+
+// ClosePopup closes the innermost popup being built, typically from a
+// button callback in its layout. Outside a popup it does nothing.
+func (c *Context) ClosePopup() {
+	if c.popupDepth == 0 {
+		ReportDiagnostic("ClosePopup called outside a popup")
+		return
+	}
+	c.closePopup = true
+}
+
+// openIfPending opens the named popup if OpenPopup asked for it
+func (c *Context) openIfPending(name string) {
+	i := slices.Index(c.pendingPopups, name)
+	if i < 0 {
+		return
+	}
+	c.pendingPopups = slices.Delete(c.pendingPopups, i, i+1)
+	imgui.OpenPopupStr(name)
+}
+
+// buildPopupLayout builds a popup's contents and closes it if a widget in
+// them called ClosePopup
+func buildPopupLayout(widgets []Widget) {
+	GlobalContext.popupDepth++
+	for _, widget := range widgets {
+		buildWidget(widget)
+	}
+	GlobalContext.popupDepth--
+	if GlobalContext.closePopup {
+		GlobalContext.closePopup = false
+		imgui.CloseCurrentPopup()
+	}
+}
+
+// PopupWidget is a floating window opened with OpenPopup and closed by
+// clicking outside it or with ClosePopup
+type PopupWidget struct {
+	name    string
+	flags   imgui.WindowFlags
+	widgets []Widget
+}
+
+// Popup creates a popup; open it with GlobalContext.OpenPopup(name)
+func Popup(name string) *PopupWidget {
+	return &PopupWidget{name: name}
+}
+
+// Flags sets the imgui window flags (builder pattern)
+func (p *PopupWidget) Flags(flags imgui.WindowFlags) *PopupWidget {
+	p.flags = flags
+	return p
+}
+
+// Layout sets the popup's contents (builder pattern)
+func (p *PopupWidget) Layout(widgets ...Widget) *PopupWidget {
+	p.widgets = widgets
+	return p
+}
+
+func (p *PopupWidget) Build() {
+	GlobalContext.openIfPending(p.name)
+	if !imgui.BeginPopupV(p.name, p.flags) {
+		return
+	}
+	buildPopupLayout(p.widgets)
+	imgui.EndPopup()
+}
+
+// PopupModalWidget is a popup that blocks the rest of the interface until
+// it is closed, centered in the window when it opens
+type PopupModalWidget struct {
+	name    string
+	flags   imgui.WindowFlags
+	open    *bool
+	widgets []Widget
+}
+
+// PopupModal creates a modal popup; open it with GlobalContext.OpenPopup(name).
+// The name is also its title; hide parts of it after "##".
+func PopupModal(name string) *PopupModalWidget {
+	return &PopupModalWidget{name: name, flags: imgui.WindowFlagsAlwaysAutoResize | imgui.WindowFlagsNoSavedSettings}
+}
+
+// Flags sets the imgui window flags, replacing the defaults (builder pattern)
+func (p *PopupModalWidget) Flags(flags imgui.WindowFlags) *PopupModalWidget {
+	p.flags = flags
+	return p
+}
+
+// NoResize stops the user resizing the modal (builder pattern)
+func (p *PopupModalWidget) NoResize() *PopupModalWidget {
+	p.flags |= imgui.WindowFlagsNoResize
+	return p
+}
+
+// NoMove stops the user dragging the modal around (builder pattern)
+func (p *PopupModalWidget) NoMove() *PopupModalWidget {
+	p.flags |= imgui.WindowFlagsNoMove
+	return p
+}
+
+// IsOpen adds a close button to the title bar, which sets *open to false
+// (builder pattern)
+func (p *PopupModalWidget) IsOpen(open *bool) *PopupModalWidget {
+	p.open = open
+	return p
+}
+
+// Layout sets the modal's contents (builder pattern)
+func (p *PopupModalWidget) Layout(widgets ...Widget) *PopupModalWidget {
+	p.widgets = widgets
+	return p
+}
+
+func (p *PopupModalWidget) Build() {
+	GlobalContext.openIfPending(p.name)
+
+	center := imgui.MainViewport().Center()
+	imgui.SetNextWindowPosV(center, imgui.CondAppearing, imgui.Vec2{X: 0.5, Y: 0.5})
+	if !imgui.BeginPopupModalV(p.name, p.open, p.flags) {
+		return
+	}
+	buildPopupLayout(p.widgets)
+	imgui.EndPopup()
+}
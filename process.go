@@ -0,0 +1,376 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// processEvent is an output line or the exit of a run, tagged with the run
+// it belongs to so output from a killed run is dropped after a restart
+type processEvent struct {
+	run    int
+	line   string
+	stderr bool
+	exited bool
+	err    error
+}
+
+// processLine is one line of captured output
+type processLine struct {
+	text   string
+	stderr bool
+}
+
+// processState holds the running command and its output
+type processState struct {
+	cmd      *exec.Cmd
+	run      int
+	events   chan processEvent
+	done     chan struct{}
+	lines    []processLine
+	running  bool
+	started  time.Time
+	finished time.Time
+	exitErr  error
+	follow   bool
+}
+
+func (s *processState) Dispose() {
+	s.kill()
+	if s.done != nil {
+		close(s.done)
+		s.done = nil
+	}
+}
+
+func (s *processState) Snapshot() any {
+	// cmd is also used by the goroutine waiting on the command, so only
+	// the fields the UI thread owns are read
+	snapshot := map[string]any{
+		"run":     s.run,
+		"running": s.running,
+		"lines":   len(s.lines),
+	}
+	if s.exitErr != nil {
+		snapshot["error"] = s.exitErr.Error()
+	}
+	return snapshot
+}
+
+// kill stops the command if it is running
+func (s *processState) kill() {
+	if s.running && s.cmd != nil && s.cmd.Process != nil {
+		s.cmd.Process.Kill()
+	}
+}
+
+// start runs the command built by newCommand, replacing any previous run
+func (s *processState) start(newCommand func() *exec.Cmd) {
+	s.kill()
+	if s.done == nil {
+		s.done = make(chan struct{})
+		s.events = make(chan processEvent, 64)
+	}
+
+	s.run++
+	s.lines, s.exitErr = nil, nil
+	s.started, s.running, s.follow = time.Now(), true, true
+
+	cmd := newCommand()
+	s.cmd = cmd
+	run, events, done := s.run, s.events, s.done
+	send := func(event processEvent) {
+		event.run = run
+		select {
+		case events <- event:
+		case <-done:
+		}
+	}
+
+	// Children the command started can keep its output open after it was
+	// killed; WaitDelay stops Wait from waiting for them forever
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
+	cmd.Stdout, cmd.Stderr = stdoutWriter, stderrWriter
+	cmd.WaitDelay = processWaitDelay
+
+	if err := cmd.Start(); err != nil {
+		go send(processEvent{exited: true, err: err})
+		return
+	}
+
+	go func() {
+		var readers sync.WaitGroup
+		readers.Add(2)
+		go streamProcessOutput(stdoutReader, false, send, &readers)
+		go streamProcessOutput(stderrReader, true, send, &readers)
+
+		err := cmd.Wait()
+		if errors.Is(err, exec.ErrWaitDelay) {
+			// The command itself succeeded; only its children lingered
+			err = nil
+		}
+		stdoutWriter.Close()
+		stderrWriter.Close()
+		readers.Wait()
+		send(processEvent{exited: true, err: err})
+	}()
+}
+
+const (
+	// processWaitDelay is how long output is still read after the command
+	// exits, before pipes held open by its children are closed
+	processWaitDelay = 2 * time.Second
+
+	// maxProcessLine is the longest line kept; the rest is dropped
+	maxProcessLine = 1024 * 1024
+)
+
+// streamProcessOutput sends each line read from pipe, cutting overlong
+// lines short. It reads until the pipe is closed so the command never
+// blocks on a full pipe.
+func streamProcessOutput(pipe io.Reader, stderr bool, send func(processEvent), readers *sync.WaitGroup) {
+	defer readers.Done()
+
+	reader := bufio.NewReaderSize(pipe, 64*1024)
+	var line []byte
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if room := maxProcessLine - len(line); room > 0 {
+			line = append(line, chunk[:min(len(chunk), room)]...)
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+
+		if err == nil || len(line) > 0 {
+			text := strings.TrimSuffix(strings.TrimSuffix(string(line), "\n"), "\r")
+			send(processEvent{line: text, stderr: stderr})
+		}
+		if err != nil {
+			// Keep draining after a read error so the writer never blocks
+			io.Copy(io.Discard, pipe)
+			return
+		}
+		line = line[:0]
+	}
+}
+
+// receive takes the events sent since the last frame, keeping at most
+// maxLines lines
+func (s *processState) receive(maxLines int) {
+	for {
+		select {
+		case event := <-s.events:
+			if event.run != s.run {
+				continue
+			}
+			if event.exited {
+				s.running, s.finished, s.exitErr = false, time.Now(), event.err
+				continue
+			}
+			s.lines = append(s.lines, processLine{text: event.line, stderr: event.stderr})
+			if maxLines > 0 && len(s.lines) > maxLines {
+				s.lines = append(s.lines[:0], s.lines[len(s.lines)-maxLines:]...)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// ProcessWidget runs an external command and shows its output, with
+// stderr in a warning color, the exit status, and kill and restart buttons
+type ProcessWidget struct {
+	id          string
+	name        string
+	args        []string
+	dir         string
+	env         []string
+	autoStart   bool
+	height      float32
+	maxLines    int
+	stderrColor imgui.Vec4
+	onExit      func(err error)
+}
+
+// Process creates a runner for the command name with args; it does not
+// start until Start is clicked unless AutoStart is set
+func Process(id, name string, args ...string) *ProcessWidget {
+	return &ProcessWidget{
+		id:          fmt.Sprintf("##process_%s", id),
+		name:        name,
+		args:        args,
+		maxLines:    10000,
+		stderrColor: imgui.Vec4{X: 1, Y: 0.55, Z: 0.4, W: 1},
+	}
+}
+
+// Dir sets the working directory of the command (builder pattern)
+func (p *ProcessWidget) Dir(dir string) *ProcessWidget {
+	p.dir = dir
+	return p
+}
+
+// Env sets the command's environment as KEY=value pairs; nil inherits ours (builder pattern)
+func (p *ProcessWidget) Env(env ...string) *ProcessWidget {
+	p.env = env
+	return p
+}
+
+// AutoStart runs the command the first time the widget is built (builder pattern)
+func (p *ProcessWidget) AutoStart(autoStart bool) *ProcessWidget {
+	p.autoStart = autoStart
+	return p
+}
+
+// Height sets the output height; zero fills the remaining space (builder pattern)
+func (p *ProcessWidget) Height(height float32) *ProcessWidget {
+	p.height = height
+	return p
+}
+
+// MaxLines sets how many output lines are kept (builder pattern)
+func (p *ProcessWidget) MaxLines(maxLines int) *ProcessWidget {
+	p.maxLines = maxLines
+	return p
+}
+
+// StderrColor sets the color of lines the command wrote to stderr (builder pattern)
+func (p *ProcessWidget) StderrColor(color imgui.Vec4) *ProcessWidget {
+	p.stderrColor = color
+	return p
+}
+
+// OnExit sets the callback invoked with the result of cmd.Wait when the command ends (builder pattern)
+func (p *ProcessWidget) OnExit(onExit func(err error)) *ProcessWidget {
+	p.onExit = onExit
+	return p
+}
+
+// Running reports whether the command is running
+func (p *ProcessWidget) Running() bool {
+	return p.getState().running
+}
+
+// Kill stops the command
+func (p *ProcessWidget) Kill() {
+	p.getState().kill()
+}
+
+// Restart stops the command if needed and runs it again
+func (p *ProcessWidget) Restart() {
+	p.getState().start(p.command)
+}
+
+// command builds a fresh exec.Cmd, since one cannot be started twice
+func (p *ProcessWidget) command() *exec.Cmd {
+	cmd := exec.Command(p.name, p.args...)
+	cmd.Dir = p.dir
+	cmd.Env = p.env
+	return cmd
+}
+
+func (p *ProcessWidget) getState() *processState {
+	if existingState, exists := GlobalContext.stateMap[p.id]; exists {
+		if state, ok := existingState.(*processState); ok {
+			return state
+		}
+	}
+
+	newState := &processState{}
+	GlobalContext.stateMap[p.id] = newState
+	if p.autoStart {
+		newState.start(p.command)
+	}
+	return newState
+}
+
+func (p *ProcessWidget) Build() {
+	state := p.getState()
+	wasRunning := state.running
+	if state.events != nil {
+		state.receive(p.maxLines)
+	}
+	if wasRunning && !state.running && p.onExit != nil {
+		p.onExit(state.exitErr)
+	}
+
+	imgui.PushIDStr(p.id)
+	defer imgui.PopID()
+
+	p.buildToolbar(state)
+
+	if !imgui.BeginChildStrV("##output", imgui.Vec2{Y: p.height}, imgui.ChildFlagsBorders, imgui.WindowFlagsHorizontalScrollbar) {
+		imgui.EndChild()
+		return
+	}
+
+	clipper := imgui.NewListClipper()
+	clipper.Begin(int32(len(state.lines)))
+	for clipper.Step() {
+		for i := clipper.DisplayStart(); i < clipper.DisplayEnd(); i++ {
+			line := state.lines[i]
+			if line.stderr {
+				imgui.TextColored(p.stderrColor, line.text)
+			} else {
+				imgui.TextUnformatted(line.text)
+			}
+		}
+	}
+	clipper.End()
+	clipper.Destroy()
+
+	if state.follow {
+		imgui.SetScrollHereYV(1)
+	}
+	state.follow = imgui.ScrollY() >= imgui.ScrollMaxY()-1
+
+	imgui.EndChild()
+}
+
+// buildToolbar draws the start, kill and restart buttons and the status
+func (p *ProcessWidget) buildToolbar(state *processState) {
+	if state.running {
+		if imgui.SmallButton("Kill") {
+			state.kill()
+		}
+		imgui.SameLine()
+		if imgui.SmallButton("Restart") {
+			state.start(p.command)
+		}
+	} else {
+		label := "Start"
+		if state.run > 0 {
+			label = "Run again"
+		}
+		if imgui.SmallButton(label) {
+			state.start(p.command)
+		}
+	}
+
+	imgui.SameLine()
+	var exitErr *exec.ExitError
+	took := state.finished.Sub(state.started).Truncate(time.Millisecond)
+	switch {
+	case state.running:
+		elapsed := time.Since(state.started).Truncate(time.Second)
+		imgui.TextDisabled(fmt.Sprintf("running %s for %s", p.name, elapsed))
+	case state.run == 0:
+		imgui.TextDisabled(p.name)
+	case state.exitErr == nil:
+		imgui.TextColored(imgui.Vec4{X: 0.4, Y: 0.85, Z: 0.4, W: 1}, fmt.Sprintf("exited with status 0 after %s", took))
+	case errors.As(state.exitErr, &exitErr):
+		imgui.TextColored(imgui.Vec4{X: 1, Y: 0.4, Z: 0.4, W: 1}, fmt.Sprintf("%s after %s", exitErr, took))
+	default:
+		imgui.TextColored(imgui.Vec4{X: 1, Y: 0.4, Z: 0.4, W: 1}, fmt.Sprintf("failed to start: %v", state.exitErr))
+	}
+}
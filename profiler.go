@@ -0,0 +1,272 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// profileFrames is how many frames are aggregated into one published profile
+const profileFrames = 60
+
+// ProfileEntry is the cost of the widgets at one path, or of one widget
+// wherever it is built, averaged per frame. Total includes the children
+// built inside the widget; Self does not.
+type ProfileEntry struct {
+	Path   string // widgets from the outermost container, e.g. `RowWidget/ComboWidget "Theme"`
+	Widget string // the widget's type and ID, the last element of Path
+	Calls  float64
+	Total  time.Duration
+	Self   time.Duration
+	Max    time.Duration // slowest single build
+}
+
+// profileSample accumulates one path across frames
+type profileSample struct {
+	widget string
+	calls  int
+	total  time.Duration
+	self   time.Duration
+	max    time.Duration
+}
+
+// profileFrame is a widget whose Build is being timed
+type profileFrame struct {
+	widget   string
+	path     string
+	start    time.Time
+	children time.Duration
+}
+
+// Profiler state
+var (
+	profiling         bool
+	profilingWanted   bool // applied between frames, see SetProfiling
+	profileStack      []profileFrame
+	profileSamples    = make(map[string]*profileSample)
+	profileFrameNum   int
+	profileStart      time.Time
+	lastProfile       []ProfileEntry
+	lastWidgetProfile []ProfileEntry
+	lastFrameTime     time.Duration
+)
+
+// SetProfiling toggles timing of every widget's Build from the next frame
+// on; widgets being built when it is called may still be timed. Timings are
+// aggregated by the path of widgets leading to each widget, and by widget.
+func SetProfiling(enabled bool) {
+	profilingWanted = enabled
+}
+
+// IsProfiling reports whether widget builds are being timed
+func IsProfiling() bool {
+	return profilingWanted
+}
+
+// Profile returns the last published profile by path, hottest first by self time
+func Profile() []ProfileEntry {
+	return append([]ProfileEntry(nil), lastProfile...)
+}
+
+// WidgetProfile returns the last published profile by widget type and ID,
+// summed over every path the widget was built at, hottest first by self time
+func WidgetProfile() []ProfileEntry {
+	return append([]ProfileEntry(nil), lastWidgetProfile...)
+}
+
+// beginProfile starts timing widget; the returned func stops it
+func beginProfile(widget Widget) func() {
+	name := widgetName(widget)
+	path := name
+	if len(profileStack) > 0 {
+		path = profileStack[len(profileStack)-1].path + "/" + name
+	}
+	profileStack = append(profileStack, profileFrame{widget: name, path: path, start: time.Now()})
+
+	return func() {
+		frame := profileStack[len(profileStack)-1]
+		profileStack = profileStack[:len(profileStack)-1]
+		elapsed := time.Since(frame.start)
+		if len(profileStack) > 0 {
+			profileStack[len(profileStack)-1].children += elapsed
+		}
+
+		sample, ok := profileSamples[frame.path]
+		if !ok {
+			sample = &profileSample{widget: frame.widget}
+			profileSamples[frame.path] = sample
+		}
+		sample.calls++
+		sample.total += elapsed
+		sample.self += elapsed - frame.children
+		sample.max = max(sample.max, elapsed)
+	}
+}
+
+// endProfileFrame publishes the profile every profileFrames frames; runs
+// after the user's loop
+func endProfileFrame() {
+	now := time.Now()
+	if !profileStart.IsZero() {
+		lastFrameTime = now.Sub(profileStart)
+	}
+	profileStart = now
+
+	// Toggling only between frames keeps every started timing paired with
+	// its end, even when a widget's callback turns profiling off
+	if profilingWanted != profiling {
+		profiling = profilingWanted
+		profileStack = profileStack[:0]
+		clear(profileSamples)
+		profileFrameNum = 0
+		if !profiling {
+			lastProfile, lastWidgetProfile = nil, nil
+		}
+		return
+	}
+
+	if !profiling {
+		return
+	}
+	profileFrameNum++
+	if profileFrameNum < profileFrames {
+		return
+	}
+
+	frames := time.Duration(profileFrameNum)
+	entries := make([]ProfileEntry, 0, len(profileSamples))
+	widgets := make(map[string]*ProfileEntry)
+	for path, sample := range profileSamples {
+		entries = append(entries, ProfileEntry{
+			Path:   path,
+			Widget: sample.widget,
+			Calls:  float64(sample.calls) / float64(profileFrameNum),
+			Total:  sample.total / frames,
+			Self:   sample.self / frames,
+			Max:    sample.max,
+		})
+
+		// A widget nested in itself would count its inner builds twice in
+		// Total, so by-widget totals only add up the self times exactly
+		widget, ok := widgets[sample.widget]
+		if !ok {
+			widget = &ProfileEntry{Path: sample.widget, Widget: sample.widget}
+			widgets[sample.widget] = widget
+		}
+		widget.Calls += float64(sample.calls) / float64(profileFrameNum)
+		widget.Total += sample.total / frames
+		widget.Self += sample.self / frames
+		widget.Max = max(widget.Max, sample.max)
+	}
+
+	byWidget := make([]ProfileEntry, 0, len(widgets))
+	for _, widget := range widgets {
+		byWidget = append(byWidget, *widget)
+	}
+	for _, list := range [][]ProfileEntry{entries, byWidget} {
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Self > list[j].Self
+		})
+	}
+
+	lastProfile, lastWidgetProfile = entries, byWidget
+	clear(profileSamples)
+	profileFrameNum = 0
+}
+
+// ProfilerViewWidget shows the frame time against a budget and the
+// hottest widget paths from the last profile
+type ProfilerViewWidget struct {
+	budget   time.Duration
+	rows     int
+	byWidget bool
+	textures []*TextureCache
+}
+
+// ProfilerView creates the profiler panel; it turns profiling on while shown
+func ProfilerView() *ProfilerViewWidget {
+	return &ProfilerViewWidget{budget: 16 * time.Millisecond, rows: 20}
+}
+
+// Budget sets the frame time the progress bar is measured against (builder pattern)
+func (p *ProfilerViewWidget) Budget(budget time.Duration) *ProfilerViewWidget {
+	p.budget = budget
+	return p
+}
+
+// Textures adds a line with the statistics of each cache (builder pattern)
+func (p *ProfilerViewWidget) Textures(caches ...*TextureCache) *ProfilerViewWidget {
+	p.textures = caches
+	return p
+}
+
+// ByWidget lists widgets by type and ID, summed over where they are built,
+// instead of by path (builder pattern)
+func (p *ProfilerViewWidget) ByWidget(byWidget bool) *ProfilerViewWidget {
+	p.byWidget = byWidget
+	return p
+}
+
+// Rows sets how many of the hottest paths are listed (builder pattern)
+func (p *ProfilerViewWidget) Rows(rows int) *ProfilerViewWidget {
+	p.rows = rows
+	return p
+}
+
+func (p *ProfilerViewWidget) Build() {
+	if !profilingWanted {
+		SetProfiling(true)
+	}
+
+	fraction := float32(lastFrameTime) / float32(p.budget)
+	overlay := fmt.Sprintf("frame %.2f ms of %.0f ms budget", float64(lastFrameTime.Microseconds())/1000, float64(p.budget.Milliseconds()))
+	if fraction > 1 {
+		PushStyleColor(imgui.ColPlotHistogram, imgui.Vec4{X: 0.9, Y: 0.3, Z: 0.3, W: 1})
+		defer PopStyleColor(1)
+	}
+	imgui.ProgressBarV(min(fraction, 1), imgui.Vec2{X: -1}, overlay)
+
+	for i, cache := range p.textures {
+		stats := cache.Stats()
+		imgui.TextDisabled(fmt.Sprintf("textures %d: %d resident, %s of %s, %d pending, %d evicted",
+			i, stats.Resident, formatSize(stats.Bytes), formatSize(stats.Budget), stats.Pending, stats.Evictions))
+	}
+
+	profile := lastProfile
+	if p.byWidget {
+		profile = lastWidgetProfile
+	}
+	if len(profile) == 0 {
+		imgui.TextDisabled("collecting...")
+		return
+	}
+
+	flags := imgui.TableFlagsRowBg | imgui.TableFlagsBorders | imgui.TableFlagsResizable
+	if !imgui.BeginTableV("##profile", 5, flags, imgui.Vec2{}, 0) {
+		return
+	}
+	imgui.TableSetupColumn("Widget")
+	imgui.TableSetupColumn("Self ms")
+	imgui.TableSetupColumn("Total ms")
+	imgui.TableSetupColumn("Max ms")
+	imgui.TableSetupColumn("Calls")
+	imgui.TableHeadersRow()
+
+	for _, entry := range profile[:min(p.rows, len(profile))] {
+		imgui.TableNextRow()
+		imgui.TableNextColumn()
+		imgui.TextUnformatted(entry.Path)
+		if imgui.IsItemHovered() {
+			imgui.SetTooltip(entry.Path)
+		}
+		for _, value := range []time.Duration{entry.Self, entry.Total, entry.Max} {
+			imgui.TableNextColumn()
+			imgui.TextUnformatted(fmt.Sprintf("%.3f", float64(value.Microseconds())/1000))
+		}
+		imgui.TableNextColumn()
+		imgui.TextUnformatted(fmt.Sprintf("%.1f", entry.Calls))
+	}
+	imgui.EndTable()
+}
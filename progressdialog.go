@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Progress reports on a long operation run by WithProgressDialog. Its
+// methods are safe to call from the operation's goroutine.
+type Progress interface {
+	// SetFraction sets the completed fraction from 0 to 1; a negative
+	// value shows an indeterminate bar
+	SetFraction(fraction float32)
+	// SetStatus sets the text shown under the bar, e.g. the current file
+	SetStatus(status string)
+	// Context is cancelled when the user clicks Cancel
+	Context() context.Context
+}
+
+// ProgressTask is an operation running under a progress dialog
+type ProgressTask struct {
+	id      string
+	ctx     context.Context
+	cancel  context.CancelFunc
+	started time.Time
+	onDone  func(err error)
+
+	mu       sync.Mutex
+	fraction float32
+	status   string
+	finished bool
+	err      error
+}
+
+// WithProgressDialog runs fn on a goroutine while a modal shows its
+// progress, sub-status, elapsed time and an ETA, with a Cancel button that
+// cancels p.Context(). The dialog closes when fn returns.
+func WithProgressDialog(title string, fn func(p Progress) error) *ProgressTask {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &ProgressTask{
+		id:       GenAutoID("##progress_dialog"),
+		ctx:      ctx,
+		cancel:   cancel,
+		started:  time.Now(),
+		fraction: -1,
+	}
+	enqueueDialog(title, t.build)
+
+	go func() {
+		defer RecoverCrash()
+		err := fn(t)
+		t.mu.Lock()
+		t.finished = true
+		t.err = err
+		t.mu.Unlock()
+	}()
+	return t
+}
+
+// OnDone sets the callback invoked on the UI thread with fn's error once
+// it returns (builder pattern)
+func (t *ProgressTask) OnDone(onDone func(err error)) *ProgressTask {
+	t.onDone = onDone
+	return t
+}
+
+// Cancel asks the operation to stop, as the Cancel button does
+func (t *ProgressTask) Cancel() {
+	t.cancel()
+}
+
+func (t *ProgressTask) SetFraction(fraction float32) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.fraction = min(fraction, 1)
+}
+
+func (t *ProgressTask) SetStatus(status string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status = status
+}
+
+func (t *ProgressTask) Context() context.Context {
+	return t.ctx
+}
+
+// build draws the dialog, reporting whether the operation has finished
+func (t *ProgressTask) build() bool {
+	t.mu.Lock()
+	fraction, status, finished, err := t.fraction, t.status, t.finished, t.err
+	t.mu.Unlock()
+
+	if finished {
+		t.cancel()
+		delete(GlobalContext.stateMap, t.id)
+		if t.onDone != nil {
+			t.onDone(err)
+		}
+		return true
+	}
+
+	bar := ProgressBar(fraction).ID(t.id).Size(400, 0)
+	if fraction < 0 {
+		bar.Indeterminate(ProgressStriped)
+	} else {
+		bar.ShowETA(true)
+	}
+	bar.Build()
+
+	imgui.Text(status)
+	imgui.TextDisabled(fmt.Sprintf("Elapsed %s", time.Since(t.started).Round(time.Second)))
+
+	imgui.Separator()
+	if t.ctx.Err() != nil {
+		imgui.BeginDisabled()
+		imgui.Button("Cancelling...")
+		imgui.EndDisabled()
+	} else if imgui.Button("Cancel") || imgui.IsKeyPressedBool(imgui.KeyEscape) {
+		t.cancel()
+	}
+	return false
+}
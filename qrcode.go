@@ -0,0 +1,549 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// QRLevel is the error correction level of a QR code; higher levels survive
+// more damage but hold less data
+type QRLevel int
+
+const (
+	QRLevelL QRLevel = iota // recovers about 7% of the symbol
+	QRLevelM                // about 15%
+	QRLevelQ                // about 25%
+	QRLevelH                // about 30%
+)
+
+// qrFormatBits are the level's bits in the format information
+var qrFormatBits = [4]int{1, 0, 3, 2}
+
+// qrECCPerBlock is the number of error correction codewords in each block,
+// by level and version
+var qrECCPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// qrBlocks is the number of error correction blocks, by level and version
+var qrBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// qrMatrix is an encoded symbol; modules[y][x] is true for dark modules
+type qrMatrix struct {
+	size     int
+	modules  [][]bool
+	function [][]bool // finder, timing, alignment and format modules, which masks skip
+}
+
+// qrRawModules is the number of modules available for data and error
+// correction in a version
+func qrRawModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		alignments := version/7 + 2
+		result -= (25*alignments-10)*alignments - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+// qrDataCodewords is the number of data codewords a version holds at a level
+func qrDataCodewords(version int, level QRLevel) int {
+	return qrRawModules(version)/8 - qrECCPerBlock[level][version]*qrBlocks[level][version]
+}
+
+// encodeQR encodes data in byte mode in the smallest version that fits
+func encodeQR(data []byte, level QRLevel) (*qrMatrix, error) {
+	version := 0
+	for v := 1; v <= 40; v++ {
+		countBits := 8
+		if v >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) <= qrDataCodewords(v, level)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("%d bytes do not fit in a QR code", len(data))
+	}
+
+	// Mode indicator, character count, data, then terminator and padding
+	var bits qrBitBuffer
+	bits.append(0b0100, 4)
+	if version >= 10 {
+		bits.append(len(data), 16)
+	} else {
+		bits.append(len(data), 8)
+	}
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	capacity := qrDataCodewords(version, level) * 8
+	bits.append(0, min(4, capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	codewords := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			codewords[i/8] |= 1 << (7 - i%8)
+		}
+	}
+
+	m := newQRMatrix(version)
+	m.drawFunctionPatterns(version, level)
+	m.drawCodewords(qrAddECC(codewords, version, level))
+
+	// Keep the mask with the lowest penalty
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		m.applyMask(mask)
+		m.drawFormatBits(level, mask)
+		if penalty := m.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		m.applyMask(mask) // masks are their own inverse
+	}
+	m.applyMask(best)
+	m.drawFormatBits(level, best)
+	return m, nil
+}
+
+// qrBitBuffer collects bits most significant first
+type qrBitBuffer []bool
+
+func (b *qrBitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, value>>i&1 != 0)
+	}
+}
+
+func newQRMatrix(version int) *qrMatrix {
+	size := version*4 + 17
+	m := &qrMatrix{size: size, modules: make([][]bool, size), function: make([][]bool, size)}
+	for y := range m.modules {
+		m.modules[y] = make([]bool, size)
+		m.function[y] = make([]bool, size)
+	}
+	return m
+}
+
+func (m *qrMatrix) setFunction(x, y int, dark bool) {
+	m.modules[y][x] = dark
+	m.function[y][x] = true
+}
+
+// drawFunctionPatterns draws everything but the data, reserving the format
+// areas so codewords skip them
+func (m *qrMatrix) drawFunctionPatterns(version int, level QRLevel) {
+	for i := 0; i < m.size; i++ {
+		m.setFunction(6, i, i%2 == 0)
+		m.setFunction(i, 6, i%2 == 0)
+	}
+
+	for _, corner := range [][2]int{{3, 3}, {m.size - 4, 3}, {3, m.size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := corner[0]+dx, corner[1]+dy
+				if x >= 0 && x < m.size && y >= 0 && y < m.size {
+					distance := max(qrAbs(dx), qrAbs(dy))
+					m.setFunction(x, y, distance != 2 && distance != 4)
+				}
+			}
+		}
+	}
+
+	positions := qrAlignmentPositions(version, m.size)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// Skip the three corners taken by finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					m.setFunction(x+dx, y+dy, max(qrAbs(dx), qrAbs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	m.drawFormatBits(level, 0)
+
+	if version >= 7 {
+		remainder := version
+		for i := 0; i < 12; i++ {
+			remainder = remainder<<1 ^ (remainder>>11)*0x1F25
+		}
+		bits := version<<12 | remainder
+		for i := 0; i < 18; i++ {
+			dark := bits>>i&1 != 0
+			a, b := m.size-11+i%3, i/3
+			m.setFunction(a, b, dark)
+			m.setFunction(b, a, dark)
+		}
+	}
+}
+
+// qrAlignmentPositions returns the centers of the alignment patterns along
+// each axis
+func qrAlignmentPositions(version, size int) []int {
+	if version == 1 {
+		return nil
+	}
+	count := version/7 + 2
+	step := (version*8 + count*3 + 5) / (count*4 - 4) * 2
+	positions := make([]int, count)
+	positions[0] = 6
+	for i, pos := count-1, size-7; i >= 1; i, pos = i-1, pos-step {
+		positions[i] = pos
+	}
+	return positions
+}
+
+// drawFormatBits writes both copies of the level and mask information
+func (m *qrMatrix) drawFormatBits(level QRLevel, mask int) {
+	data := qrFormatBits[level]<<3 | mask
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = remainder<<1 ^ (remainder>>9)*0x537
+	}
+	bits := (data<<10 | remainder) ^ 0x5412
+	bit := func(i int) bool { return bits>>i&1 != 0 }
+
+	for i := 0; i <= 5; i++ {
+		m.setFunction(8, i, bit(i))
+	}
+	m.setFunction(8, 7, bit(6))
+	m.setFunction(8, 8, bit(7))
+	m.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		m.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		m.setFunction(m.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		m.setFunction(8, m.size-15+i, bit(i))
+	}
+	m.setFunction(8, m.size-8, true)
+}
+
+// drawCodewords fills the data area in the zigzag order of the standard
+func (m *qrMatrix) drawCodewords(codewords []byte) {
+	i := 0
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		for vertical := 0; vertical < m.size; vertical++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vertical
+				if (right+1)&2 == 0 {
+					y = m.size - 1 - vertical
+				}
+				if !m.function[y][x] && i < len(codewords)*8 {
+					m.modules[y][x] = codewords[i/8]>>(7-i%8)&1 != 0
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask inverts the data modules selected by mask
+func (m *qrMatrix) applyMask(mask int) {
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !m.function[y][x] {
+				m.modules[y][x] = !m.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores how hard the symbol is to scan, per the four rules of the
+// standard: long runs, 2x2 blocks, finder-like patterns and dark balance
+func (m *qrMatrix) penalty() int {
+	score := 0
+	at := func(x, y int, vertical bool) bool {
+		if vertical {
+			return m.modules[x][y]
+		}
+		return m.modules[y][x]
+	}
+
+	finderLike := [2][11]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+	for _, vertical := range []bool{false, true} {
+		for y := 0; y < m.size; y++ {
+			run := 1
+			for x := 1; x <= m.size; x++ {
+				if x < m.size && at(x, y, vertical) == at(x-1, y, vertical) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += run - 2
+				}
+				run = 1
+			}
+
+			for x := 0; x+11 <= m.size; x++ {
+				for _, pattern := range finderLike {
+					matches := true
+					for k, dark := range pattern {
+						if at(x+k, y, vertical) != dark {
+							matches = false
+							break
+						}
+					}
+					if matches {
+						score += 40
+					}
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if m.modules[y][x] {
+				dark++
+			}
+			if x+1 < m.size && y+1 < m.size {
+				c := m.modules[y][x]
+				if c == m.modules[y][x+1] && c == m.modules[y+1][x] && c == m.modules[y+1][x+1] {
+					score += 3
+				}
+			}
+		}
+	}
+	total := m.size * m.size
+	score += qrAbs(dark*20-total*10) / total * 10
+	return score
+}
+
+// qrAddECC splits data into blocks, appends Reed-Solomon codewords to each
+// and interleaves them
+func qrAddECC(data []byte, version int, level QRLevel) []byte {
+	blockCount := qrBlocks[level][version]
+	eccLength := qrECCPerBlock[level][version]
+	raw := qrRawModules(version) / 8
+	shortBlocks := blockCount - raw%blockCount
+	shortLength := raw / blockCount
+
+	divisor := qrReedSolomonDivisor(eccLength)
+	blocks := make([][]byte, blockCount)
+	offset := 0
+	for i := range blocks {
+		length := shortLength - eccLength
+		if i >= shortBlocks {
+			length++
+		}
+		block := append([]byte(nil), data[offset:offset+length]...)
+		offset += length
+		ecc := qrReedSolomonRemainder(block, divisor)
+		if i < shortBlocks {
+			block = append(block, 0) // placeholder so all blocks align
+		}
+		blocks[i] = append(block, ecc...)
+	}
+
+	result := make([]byte, 0, raw)
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			if i != shortLength-eccLength || j >= shortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// qrReedSolomonDivisor returns the generator polynomial of the given degree
+func qrReedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = qrMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = qrMultiply(root, 0x02)
+	}
+	return result
+}
+
+// qrReedSolomonRemainder returns the error correction codewords for data
+func qrReedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coefficient := range divisor {
+			result[i] ^= qrMultiply(coefficient, factor)
+		}
+	}
+	return result
+}
+
+// qrMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1
+func qrMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11D
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
+
+func qrAbs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// qrCodeState caches the encoded symbol for the data last shown
+type qrCodeState struct {
+	data   string
+	level  QRLevel
+	matrix *qrMatrix
+	err    error
+}
+
+func (s *qrCodeState) Dispose() {
+	// Nothing to clean up
+}
+
+// QRCodeWidget draws a QR code with the draw list
+type QRCodeWidget struct {
+	data  string
+	size  float32
+	level QRLevel
+	dark  imgui.Vec4
+	light imgui.Vec4
+}
+
+// QRCode creates a QR code encoding data as bytes
+func QRCode(data string) *QRCodeWidget {
+	return &QRCodeWidget{
+		data:  data,
+		size:  160,
+		level: QRLevelM,
+		dark:  imgui.Vec4{X: 0, Y: 0, Z: 0, W: 1},
+		light: imgui.Vec4{X: 1, Y: 1, Z: 1, W: 1},
+	}
+}
+
+// Size sets the width and height including the quiet zone (builder pattern)
+func (q *QRCodeWidget) Size(size float32) *QRCodeWidget {
+	q.size = size
+	return q
+}
+
+// Level sets the error correction level (builder pattern)
+func (q *QRCodeWidget) Level(level QRLevel) *QRCodeWidget {
+	q.level = level
+	return q
+}
+
+// Colors sets the module and background colors; scanners need strong
+// contrast (builder pattern)
+func (q *QRCodeWidget) Colors(dark, light imgui.Vec4) *QRCodeWidget {
+	q.dark = dark
+	q.light = light
+	return q
+}
+
+func (q *QRCodeWidget) getState() *qrCodeState {
+	id := fmt.Sprintf("##qrcode_%s", q.data)
+	if existingState, exists := GlobalContext.stateMap[id]; exists {
+		if state, ok := existingState.(*qrCodeState); ok {
+			return state
+		}
+	}
+
+	newState := &qrCodeState{}
+	GlobalContext.stateMap[id] = newState
+	return newState
+}
+
+func (q *QRCodeWidget) Build() {
+	state := q.getState()
+	if (state.matrix == nil && state.err == nil) || state.level != q.level {
+		state.data, state.level = q.data, q.level
+		state.matrix, state.err = encodeQR([]byte(q.data), q.level)
+	}
+	if state.err != nil {
+		ReportDiagnostic(state.err.Error())
+		return
+	}
+
+	pos := imgui.CursorScreenPos()
+	imgui.Dummy(imgui.Vec2{X: q.size, Y: q.size})
+
+	// Four modules of quiet zone on every side
+	const quietZone = 4
+	matrix := state.matrix
+	module := q.size / float32(matrix.size+2*quietZone)
+	origin := pos.Add(imgui.Vec2{X: module * quietZone, Y: module * quietZone})
+
+	drawList := imgui.WindowDrawList()
+	drawList.AddRectFilled(pos, pos.Add(imgui.Vec2{X: q.size, Y: q.size}), imgui.ColorU32Vec4(q.light))
+	dark := imgui.ColorU32Vec4(q.dark)
+	for y, row := range matrix.modules {
+		for x, set := range row {
+			if !set {
+				continue
+			}
+			cell := origin.Add(imgui.Vec2{X: float32(x) * module, Y: float32(y) * module})
+			drawList.AddRectFilled(cell, cell.Add(imgui.Vec2{X: module, Y: module}), dark)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// queryPageSize is how many rows the background reader fetches at a time
+const queryPageSize = 200
+
+// RowIterator is the driver-agnostic result set read by QueryGrid;
+// *sql.Rows implements it
+type RowIterator interface {
+	Columns() ([]string, error)
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+	Close() error
+}
+
+// columnTyper is implemented by *sql.Rows
+type columnTyper interface {
+	ColumnTypes() ([]*sql.ColumnType, error)
+}
+
+// queryCell is one formatted value; NULL is kept apart from the text "NULL"
+type queryCell struct {
+	text string
+	null bool
+}
+
+// queryPage is a batch of rows from the background reader
+type queryPage struct {
+	rows [][]queryCell
+	err  error
+	done bool
+}
+
+// formatQueryValue formats a scanned value for display
+func formatQueryValue(value any) queryCell {
+	switch v := value.(type) {
+	case nil:
+		return queryCell{null: true}
+	case []byte:
+		return queryCell{text: string(v)}
+	case time.Time:
+		return queryCell{text: v.Format(time.RFC3339)}
+	default:
+		return queryCell{text: fmt.Sprint(v)}
+	}
+}
+
+// queryModel is a TableModel over a result set read in pages on demand.
+// Sorting and filtering apply to the rows loaded so far.
+type queryModel struct {
+	source  RowIterator
+	columns []string
+	types   []string
+	rows    [][]queryCell
+	visible []int
+
+	filter    string
+	sorted    bool
+	sortCol   int
+	sortOrder SortDirection
+
+	pages     chan queryPage
+	more      chan struct{}
+	stop      chan struct{}
+	requested bool
+	done      bool
+	err       error
+}
+
+func newQueryModel(source RowIterator) *queryModel {
+	m := &queryModel{
+		source: source,
+		pages:  make(chan queryPage, 1),
+		more:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+	}
+
+	columns, err := source.Columns()
+	if err != nil {
+		m.err = err
+		m.done = true
+		source.Close()
+		return m
+	}
+	m.columns = columns
+	m.types = make([]string, len(columns))
+	if typer, ok := source.(columnTyper); ok {
+		if types, err := typer.ColumnTypes(); err == nil {
+			for i, columnType := range types {
+				m.types[i] = columnType.DatabaseTypeName()
+			}
+		}
+	}
+
+	go m.read()
+	m.request()
+	return m
+}
+
+// read runs on its own goroutine, reading a page each time one is requested
+func (m *queryModel) read() {
+	defer m.source.Close()
+
+	values := make([]any, len(m.columns))
+	pointers := make([]any, len(m.columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	for {
+		select {
+		case <-m.more:
+		case <-m.stop:
+			return
+		}
+
+		var page queryPage
+		for len(page.rows) < queryPageSize {
+			if !m.source.Next() {
+				page.err = m.source.Err()
+				page.done = true
+				break
+			}
+			if err := m.source.Scan(pointers...); err != nil {
+				page.err = err
+				page.done = true
+				break
+			}
+			row := make([]queryCell, len(values))
+			for i, value := range values {
+				row[i] = formatQueryValue(value)
+			}
+			page.rows = append(page.rows, row)
+		}
+
+		select {
+		case m.pages <- page:
+		case <-m.stop:
+			return
+		}
+		if page.done {
+			return
+		}
+	}
+}
+
+// request asks the reader for the next page unless one is pending
+func (m *queryModel) request() {
+	if m.done || m.requested {
+		return
+	}
+	m.requested = true
+	m.more <- struct{}{}
+}
+
+// receive adds a finished page, keeping the filter and sort order
+func (m *queryModel) receive() {
+	select {
+	case page := <-m.pages:
+		m.requested = false
+		m.done = page.done
+		m.err = page.err
+
+		first := len(m.rows)
+		m.rows = append(m.rows, page.rows...)
+		for i := first; i < len(m.rows); i++ {
+			if m.matches(i) {
+				m.visible = append(m.visible, i)
+			}
+		}
+		if m.sorted {
+			m.Sort(m.sortCol, m.sortOrder)
+		}
+	default:
+	}
+}
+
+// close stops the reader, which closes the result set
+func (m *queryModel) close() {
+	close(m.stop)
+}
+
+func (m *queryModel) matches(row int) bool {
+	if m.filter == "" {
+		return true
+	}
+	for _, cell := range m.rows[row] {
+		if !cell.null && strings.Contains(strings.ToLower(cell.text), m.filter) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *queryModel) ColumnCount() int          { return len(m.columns) }
+func (m *queryModel) ColumnName(col int) string { return m.columns[col] }
+func (m *queryModel) ColumnType(col int) string { return m.types[col] }
+func (m *queryModel) RowCount() int             { return len(m.visible) }
+func (m *queryModel) Cell(row, col int) string  { return m.rows[m.visible[row]][col].text }
+func (m *queryModel) IsNull(row, col int) bool  { return m.rows[m.visible[row]][col].null }
+
+// Sort orders the loaded rows; NULLs sort before any value
+func (m *queryModel) Sort(col int, direction SortDirection) {
+	m.sorted, m.sortCol, m.sortOrder = true, col, direction
+	sort.SliceStable(m.visible, func(i, j int) bool {
+		a, b := m.rows[m.visible[i]][col], m.rows[m.visible[j]][col]
+		if direction == SortDescending {
+			a, b = b, a
+		}
+		if a.null || b.null {
+			return a.null && !b.null
+		}
+		return a.text < b.text
+	})
+}
+
+func (m *queryModel) Filter(query string) {
+	m.filter = strings.ToLower(query)
+	m.visible = m.visible[:0]
+	for i := range m.rows {
+		if m.matches(i) {
+			m.visible = append(m.visible, i)
+		}
+	}
+	if m.sorted {
+		m.Sort(m.sortCol, m.sortOrder)
+	}
+}
+
+// Fetch requests the next page once the view nears the end of the loaded rows
+func (m *queryModel) Fetch(first, last int) {
+	if last+queryPageSize/2 >= len(m.visible) {
+		m.request()
+	}
+}
+
+// queryGridState keeps the model for the result set being shown
+type queryGridState struct {
+	source RowIterator
+	model  *queryModel
+}
+
+func (s *queryGridState) Dispose() {
+	if s.model != nil {
+		s.model.close()
+		s.model = nil
+	}
+}
+
+// QueryGridWidget browses a query result, reading rows in pages on a
+// background goroutine as the user scrolls
+type QueryGridWidget struct {
+	id     string
+	rows   RowIterator
+	height float32
+}
+
+// QueryGrid creates a browser for rows; passing a different iterator
+// replaces the result set. The grid closes the iterator when done.
+func QueryGrid(id string, rows RowIterator) *QueryGridWidget {
+	return &QueryGridWidget{id: id, rows: rows}
+}
+
+// Height sets the grid height; zero fills the remaining space (builder pattern)
+func (q *QueryGridWidget) Height(height float32) *QueryGridWidget {
+	q.height = height
+	return q
+}
+
+func (q *QueryGridWidget) getState() *queryGridState {
+	id := fmt.Sprintf("##querygrid_%s", q.id)
+	if existingState, exists := GlobalContext.stateMap[id]; exists {
+		if state, ok := existingState.(*queryGridState); ok {
+			return state
+		}
+	}
+
+	newState := &queryGridState{}
+	GlobalContext.stateMap[id] = newState
+	return newState
+}
+
+func (q *QueryGridWidget) Build() {
+	if q.rows == nil {
+		ReportDiagnostic(fmt.Sprintf("query grid %q has no rows", q.id))
+		return
+	}
+
+	state := q.getState()
+	if state.source != q.rows {
+		state.Dispose()
+		state.source = q.rows
+		state.model = newQueryModel(q.rows)
+	}
+	model := state.model
+	model.receive()
+
+	// Leave room for the status line below the grid
+	height := q.height
+	if height <= 0 {
+		height = -imgui.FrameHeightWithSpacing()
+	}
+	DataGrid(q.id, model).Height(height).Filterable(true).Build()
+
+	switch {
+	case model.err != nil:
+		imgui.TextColored(imgui.Vec4{X: 1, Y: 0.4, Z: 0.4, W: 1}, model.err.Error())
+	case model.done:
+		imgui.TextDisabled(fmt.Sprintf("%d rows", len(model.rows)))
+	default:
+		imgui.TextDisabled(fmt.Sprintf("%d rows loaded, scroll for more", len(model.rows)))
+	}
+}
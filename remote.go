@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+)
+
+// RemoteRequest is a single command sent to the remote control endpoint.
+// Requests and responses are newline-delimited JSON.
+type RemoteRequest struct {
+	Token   string   `json:"token"`   // the token passed to StartRemoteControl
+	Command string   `json:"command"` // "actions", "state", "trigger" or "screenshot"
+	Name    string   `json:"name,omitempty"`
+	Args    []string `json:"args,omitempty"`
+}
+
+// RemoteResponse is the reply to a RemoteRequest
+type RemoteResponse struct {
+	OK     bool        `json:"ok"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// remoteCall is a request waiting to be executed on the UI thread
+type remoteCall struct {
+	request RemoteRequest
+	reply   chan RemoteResponse
+}
+
+// RemoteServer exposes a running app to external automation tools
+type RemoteServer struct {
+	listener   net.Listener
+	socketPath string
+	token      string
+	actions    map[string]func(args []string) error
+	calls      chan remoteCall
+}
+
+// Global remote server instance, nil unless StartRemoteControl was called
+var remoteServer *RemoteServer
+
+// ErrScreenshotUnsupported is returned for screenshot requests, since the
+// backend does not give access to the rendered framebuffer
+var ErrScreenshotUnsupported = errors.New("screenshot capture is not supported by the current backend")
+
+// StartRemoteControl starts the automation endpoint on a local unix socket.
+// The socket is only accessible to the current user, and every request must
+// carry token. Commands are executed on the UI thread between frames.
+func StartRemoteControl(socketPath, token string) (*RemoteServer, error) {
+	if token == "" {
+		return nil, errors.New("starting remote control: a token is required")
+	}
+
+	// Only a socket nobody answers on is stale and safe to replace
+	if conn, err := net.Dial("unix", socketPath); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("starting remote control on %s: socket is in use", socketPath)
+	}
+	os.Remove(socketPath)
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("starting remote control on %s: %w", socketPath, err)
+	}
+	if err := os.Chmod(socketPath, 0o600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("restricting remote control socket %s: %w", socketPath, err)
+	}
+
+	remoteServer = &RemoteServer{
+		listener:   listener,
+		socketPath: socketPath,
+		token:      token,
+		actions:    make(map[string]func(args []string) error),
+		calls:      make(chan remoteCall),
+	}
+	go remoteServer.serve()
+
+	return remoteServer, nil
+}
+
+// RegisterAction makes an action triggerable by name (builder pattern)
+func (r *RemoteServer) RegisterAction(name string, action func(args []string) error) *RemoteServer {
+	r.actions[name] = action
+	return r
+}
+
+// Close stops the endpoint and removes the socket file
+func (r *RemoteServer) Close() error {
+	err := r.listener.Close()
+	os.Remove(r.socketPath)
+	if remoteServer == r {
+		remoteServer = nil
+	}
+	return err
+}
+
+// serve accepts automation clients
+func (r *RemoteServer) serve() {
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			return
+		}
+		go r.handleConn(conn)
+	}
+}
+
+// handleConn reads requests from one client and writes back the responses
+func (r *RemoteServer) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	encoder := json.NewEncoder(conn)
+
+	for scanner.Scan() {
+		var request RemoteRequest
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			encoder.Encode(RemoteResponse{Error: fmt.Sprintf("invalid request: %v", err)})
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(request.Token), []byte(r.token)) != 1 {
+			encoder.Encode(RemoteResponse{Error: "invalid token"})
+			return
+		}
+
+		call := remoteCall{request: request, reply: make(chan RemoteResponse, 1)}
+		r.calls <- call
+
+		if err := encoder.Encode(<-call.reply); err != nil {
+			return
+		}
+	}
+}
+
+// processCalls executes pending requests; must run on the UI thread
+func (r *RemoteServer) processCalls() {
+	for {
+		select {
+		case call := <-r.calls:
+			call.reply <- r.execute(call.request)
+		default:
+			return
+		}
+	}
+}
+
+// execute runs a single request against the app state
+func (r *RemoteServer) execute(request RemoteRequest) RemoteResponse {
+	switch request.Command {
+	case "actions":
+		names := make([]string, 0, len(r.actions))
+		for name := range r.actions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return RemoteResponse{OK: true, Result: names}
+
+	case "state":
+		if request.Name != "" {
+			state, exists := GlobalContext.stateMap[request.Name]
+			if !exists {
+				return RemoteResponse{Error: fmt.Sprintf("no widget state for %q", request.Name)}
+			}
+			snapshotter, ok := state.(StateSnapshotter)
+			if !ok {
+				return RemoteResponse{Error: fmt.Sprintf("widget state %q can't be read remotely", request.Name)}
+			}
+			return RemoteResponse{OK: true, Result: snapshotter.Snapshot()}
+		}
+
+		states := make(map[string]any, len(GlobalContext.stateMap))
+		for id, state := range GlobalContext.stateMap {
+			if snapshotter, ok := state.(StateSnapshotter); ok {
+				states[id] = snapshotter.Snapshot()
+			}
+		}
+		return RemoteResponse{OK: true, Result: states}
+
+	case "trigger":
+		action, exists := r.actions[request.Name]
+		if !exists {
+			return RemoteResponse{Error: fmt.Sprintf("unknown action %q", request.Name)}
+		}
+		if err := action(request.Args); err != nil {
+			return RemoteResponse{Error: err.Error()}
+		}
+		LogStatus(fmt.Sprintf("Remote action triggered: %s", request.Name))
+		return RemoteResponse{OK: true}
+
+	case "screenshot":
+		return RemoteResponse{Error: ErrScreenshotUnsupported.Error()}
+	}
+
+	return RemoteResponse{Error: fmt.Sprintf("unknown command %q", request.Command)}
+}
+
+// StateSnapshotter is implemented by widget states the "state" command can
+// read. Snapshot runs on the UI thread and returns plain values for JSON;
+// a state shared with a goroutine takes its lock to copy them. Other
+// states are not exposed.
+type StateSnapshotter interface {
+	Snapshot() any
+}
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Setting is one option shown by SettingsWindow, bound to a variable
+type Setting struct {
+	key         string
+	label       string
+	description string
+
+	get     func() any
+	set     func(value any)
+	def     any
+	decode  func(data json.RawMessage) (any, error)
+	control func(id string, value any) (any, bool)
+}
+
+// newSetting creates a setting over value; control draws the editor for a
+// draft copy and reports whether it changed
+func newSetting[T comparable](key, label string, value *T, def T, control func(id string, draft *T) bool) *Setting {
+	return &Setting{
+		key:   key,
+		label: label,
+		get:   func() any { return *value },
+		set:   func(v any) { *value = v.(T) },
+		def:   def,
+		decode: func(data json.RawMessage) (any, error) {
+			var v T
+			err := json.Unmarshal(data, &v)
+			return v, err
+		},
+		control: func(id string, v any) (any, bool) {
+			draft := v.(T)
+			changed := control(id, &draft)
+			return draft, changed
+		},
+	}
+}
+
+// BoolSetting creates a checkbox option
+func BoolSetting(key, label string, value *bool, def bool) *Setting {
+	return newSetting(key, label, value, def, func(id string, draft *bool) bool {
+		return imgui.Checkbox(id, draft)
+	})
+}
+
+// IntSetting creates a slider option over [min, max]
+func IntSetting(key, label string, value *int32, def, min, max int32) *Setting {
+	return newSetting(key, label, value, def, func(id string, draft *int32) bool {
+		return imgui.SliderInt(id, draft, min, max)
+	})
+}
+
+// FloatSetting creates a slider option over [min, max]
+func FloatSetting(key, label string, value *float32, def, min, max float32) *Setting {
+	return newSetting(key, label, value, def, func(id string, draft *float32) bool {
+		return imgui.SliderFloatV(id, draft, min, max, "%.2f", 0)
+	})
+}
+
+// StringSetting creates a text option
+func StringSetting(key, label string, value *string, def string) *Setting {
+	return newSetting(key, label, value, def, func(id string, draft *string) bool {
+		return imgui.InputTextWithHint(id, "", draft, 0, nil)
+	})
+}
+
+// ChoiceSetting creates a dropdown option; value is the chosen index
+func ChoiceSetting(key, label string, value *int32, def int32, choices []string) *Setting {
+	return newSetting(key, label, value, def, func(id string, draft *int32) bool {
+		changed := false
+		Combo(id, draft, choices).OnChange(func() { changed = true }).Build()
+		return changed
+	})
+}
+
+// Description sets the help text shown below the option (builder pattern)
+func (s *Setting) Description(description string) *Setting {
+	s.description = description
+	return s
+}
+
+// matches reports whether the option's label or description contains query
+func (s *Setting) matches(query string) bool {
+	return strings.Contains(strings.ToLower(s.label), query) ||
+		strings.Contains(strings.ToLower(s.description), query)
+}
+
+// SettingsSection is a category of options in the sidebar
+type SettingsSection struct {
+	name     string
+	settings []*Setting
+}
+
+// Section creates a settings category
+func Section(name string, settings ...*Setting) *SettingsSection {
+	return &SettingsSection{name: name, settings: settings}
+}
+
+// SaveSettings writes the current values of every option to a JSON file
+func SaveSettings(path string, sections ...*SettingsSection) error {
+	values := make(map[string]any)
+	for _, section := range sections {
+		for _, setting := range section.settings {
+			values[setting.key] = setting.get()
+		}
+	}
+
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return fmt.Errorf("saving settings: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("saving settings: %w", err)
+	}
+	return nil
+}
+
+// LoadSettings reads values saved by SaveSettings into the bound
+// variables. Options missing from the file get their defaults; a missing
+// file is not an error.
+func LoadSettings(path string, sections ...*SettingsSection) error {
+	values := make(map[string]json.RawMessage)
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("loading settings: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &values); err != nil {
+			return fmt.Errorf("loading settings from %s: %w", path, err)
+		}
+	}
+
+	for _, section := range sections {
+		for _, setting := range section.settings {
+			raw, ok := values[setting.key]
+			if !ok {
+				setting.set(setting.def)
+				continue
+			}
+			value, err := setting.decode(raw)
+			if err != nil {
+				return fmt.Errorf("loading setting %s: %w", setting.key, err)
+			}
+			setting.set(value)
+		}
+	}
+	return nil
+}
+
+// settingsState holds the edited values until they are applied
+type settingsState struct {
+	drafts  map[string]any
+	section int
+	search  string
+	err     error
+}
+
+func (s *settingsState) Dispose() {
+	// Nothing to clean up
+}
+
+// SettingsWindowWidget is a categorized settings editor: a searchable
+// sidebar of sections and Apply, Revert and Defaults buttons. Edits only
+// reach the bound variables when applied.
+type SettingsWindowWidget struct {
+	id       string
+	sections []*SettingsSection
+	path     string
+	onApply  func()
+	height   float32
+}
+
+// SettingsWindow creates a settings editor over sections
+func SettingsWindow(id string, sections ...*SettingsSection) *SettingsWindowWidget {
+	return &SettingsWindowWidget{
+		id:       fmt.Sprintf("##settings_%s", id),
+		sections: sections,
+	}
+}
+
+// File saves the settings to path with SaveSettings on Apply (builder pattern)
+func (s *SettingsWindowWidget) File(path string) *SettingsWindowWidget {
+	s.path = path
+	return s
+}
+
+// OnApply sets the callback invoked after the edits are applied (builder pattern)
+func (s *SettingsWindowWidget) OnApply(onApply func()) *SettingsWindowWidget {
+	s.onApply = onApply
+	return s
+}
+
+// Height sets the editor height; zero fills the remaining space (builder pattern)
+func (s *SettingsWindowWidget) Height(height float32) *SettingsWindowWidget {
+	s.height = height
+	return s
+}
+
+func (s *SettingsWindowWidget) getState() *settingsState {
+	if existingState, exists := GlobalContext.stateMap[s.id]; exists {
+		if state, ok := existingState.(*settingsState); ok {
+			return state
+		}
+	}
+
+	newState := &settingsState{drafts: make(map[string]any)}
+	GlobalContext.stateMap[s.id] = newState
+	return newState
+}
+
+// draft returns the edited value of a setting, or its current value
+func (s *settingsState) draft(setting *Setting) any {
+	if value, ok := s.drafts[setting.key]; ok {
+		return value
+	}
+	return setting.get()
+}
+
+// dirty reports whether any draft differs from its bound value
+func (s *SettingsWindowWidget) dirty(state *settingsState) bool {
+	for _, section := range s.sections {
+		for _, setting := range section.settings {
+			if value, ok := state.drafts[setting.key]; ok && value != setting.get() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *SettingsWindowWidget) Build() {
+	state := s.getState()
+	imgui.PushIDStr(s.id)
+	defer imgui.PopID()
+
+	// Leave room for the button row
+	height := s.height
+	if height <= 0 {
+		height = imgui.ContentRegionAvail().Y
+	}
+	height -= imgui.FrameHeightWithSpacing()
+
+	if imgui.BeginChildStrV("##sidebar", imgui.Vec2{X: 180, Y: height}, imgui.ChildFlagsBorders, 0) {
+		imgui.SetNextItemWidth(-1)
+		imgui.InputTextWithHint("##search", "Search", &state.search, 0, nil)
+		for i, section := range s.sections {
+			if imgui.SelectableBoolV(section.name, state.search == "" && state.section == i, 0, imgui.Vec2{}) {
+				state.section = i
+				state.search = ""
+			}
+		}
+	}
+	imgui.EndChild()
+
+	imgui.SameLine()
+	if imgui.BeginChildStrV("##options", imgui.Vec2{Y: height}, imgui.ChildFlagsBorders, 0) {
+		s.buildOptions(state)
+	}
+	imgui.EndChild()
+
+	s.buildButtons(state)
+}
+
+// buildOptions draws the current section, or every match while searching
+func (s *SettingsWindowWidget) buildOptions(state *settingsState) {
+	query := strings.ToLower(strings.TrimSpace(state.search))
+	if query == "" {
+		if state.section >= len(s.sections) {
+			return
+		}
+		section := s.sections[state.section]
+		imgui.SeparatorText(section.name)
+		for _, setting := range section.settings {
+			s.buildSetting(state, setting)
+		}
+		return
+	}
+
+	found := false
+	for _, section := range s.sections {
+		shown := false
+		for _, setting := range section.settings {
+			if !setting.matches(query) {
+				continue
+			}
+			if !shown {
+				imgui.SeparatorText(section.name)
+				shown = true
+			}
+			s.buildSetting(state, setting)
+		}
+		found = found || shown
+	}
+	if !found {
+		imgui.TextDisabled(fmt.Sprintf("No settings match %q", state.search))
+	}
+}
+
+// buildSetting draws one option editing its draft value
+func (s *SettingsWindowWidget) buildSetting(state *settingsState, setting *Setting) {
+	value, changed := setting.control(fmt.Sprintf("%s##%s", setting.label, setting.key), state.draft(setting))
+	if changed {
+		state.drafts[setting.key] = value
+	}
+	if setting.description != "" {
+		imgui.Indent()
+		imgui.TextDisabled(setting.description)
+		imgui.Unindent()
+	}
+	imgui.Spacing()
+}
+
+// buildButtons draws Apply, Revert and Defaults and any save error
+func (s *SettingsWindowWidget) buildButtons(state *settingsState) {
+	dirty := s.dirty(state)
+
+	imgui.BeginDisabledV(!dirty)
+	if imgui.Button("Apply") {
+		s.apply(state)
+	}
+	imgui.SameLine()
+	if imgui.Button("Revert") {
+		clear(state.drafts)
+	}
+	imgui.EndDisabled()
+
+	imgui.SameLine()
+	if imgui.Button("Defaults") {
+		for _, section := range s.sections {
+			for _, setting := range section.settings {
+				state.drafts[setting.key] = setting.def
+			}
+		}
+	}
+
+	if state.err != nil {
+		imgui.SameLine()
+		imgui.TextColored(imgui.Vec4{X: 1, Y: 0.4, Z: 0.4, W: 1}, state.err.Error())
+	}
+}
+
+// apply writes the drafts to the bound variables and saves them
+func (s *SettingsWindowWidget) apply(state *settingsState) {
+	for _, section := range s.sections {
+		for _, setting := range section.settings {
+			if value, ok := state.drafts[setting.key]; ok {
+				setting.set(value)
+			}
+		}
+	}
+	clear(state.drafts)
+
+	state.err = nil
+	if s.path != "" {
+		state.err = SaveSettings(s.path, s.sections...)
+	}
+	if s.onApply != nil {
+		s.onApply()
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// keysByName maps lower-case key names to keys, built on first use
+var keysByName map[string]imgui.Key
+
+// ParseKeyChord parses chords like "Ctrl+Shift+S" or "Alt+F4"; modifier
+// and key names are case-insensitive
+func ParseKeyChord(text string) (KeyChord, error) {
+	if keysByName == nil {
+		keysByName = make(map[string]imgui.Key)
+		for key := imgui.KeyNamedKeyBEGIN; key < imgui.KeyNamedKeyEND; key++ {
+			keysByName[strings.ToLower(imgui.KeyName(key))] = key
+		}
+	}
+
+	var chord KeyChord
+	parts := strings.Split(text, "+")
+	for i, part := range parts {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if i < len(parts)-1 {
+			switch name {
+			case "ctrl", "control":
+				chord.Ctrl = true
+			case "shift":
+				chord.Shift = true
+			case "alt", "option":
+				chord.Alt = true
+			case "super", "cmd", "win", "meta":
+				chord.Super = true
+			default:
+				return KeyChord{}, fmt.Errorf("unknown modifier %q in shortcut %q", part, text)
+			}
+			continue
+		}
+
+		key, ok := keysByName[name]
+		if !ok || isModifierKey(key) {
+			return KeyChord{}, fmt.Errorf("unknown key %q in shortcut %q", part, text)
+		}
+		chord.Key = key
+	}
+	return chord, nil
+}
+
+// Pressed reports whether the chord was pressed this frame with exactly its modifiers
+func (c KeyChord) Pressed() bool {
+	if c.Key == imgui.KeyNone || !imgui.IsKeyPressedBoolV(c.Key, false) {
+		return false
+	}
+	io := imgui.CurrentIO()
+	return io.KeyCtrl() == c.Ctrl && io.KeyShift() == c.Shift && io.KeyAlt() == c.Alt && io.KeySuper() == c.Super
+}
+
+// shortcuts is the application-wide shortcut registry
+var shortcuts = make(map[KeyChord]func())
+
+// RegisterShortcut runs callback whenever chord is pressed, whether or not
+// the widget that registered it is visible. A later registration of the
+// same chord replaces the earlier one.
+func RegisterShortcut(chord KeyChord, callback func()) {
+	shortcuts[chord] = callback
+}
+
+// UnregisterShortcut removes the callback registered for chord
+func UnregisterShortcut(chord KeyChord) {
+	delete(shortcuts, chord)
+}
+
+// processShortcuts runs the callbacks of the chords pressed this frame.
+// While a text field has focus, chords without Ctrl, Alt or Super are left
+// to the field.
+func processShortcuts() {
+	typing := imgui.CurrentIO().WantTextInput()
+	for chord, callback := range shortcuts {
+		if callback == nil || (typing && !chord.Ctrl && !chord.Alt && !chord.Super) {
+			continue
+		}
+		if chord.Pressed() {
+			callback()
+		}
+	}
+}
+
+// Shortcut binds a chord like "Ctrl+S" to the button's OnClick and shows it
+// right-aligned on the button (builder pattern)
+func (b *ButtonWidget) Shortcut(shortcut string) *ButtonWidget {
+	chord, err := ParseKeyChord(shortcut)
+	if err != nil {
+		LogWarning(err.Error())
+		return b
+	}
+	b.shortcut = &chord
+	return b
+}
+
+// shortcutButtonSize widens an automatic button size to fit the hint
+func shortcutButtonSize(label, hint string, size imgui.Vec2) imgui.Vec2 {
+	if size.X > 0 {
+		return size
+	}
+
+	label, _, _ = strings.Cut(label, "##")
+	style := imgui.CurrentStyle()
+	size.X = imgui.CalcTextSize(label).X + style.ItemSpacing().X*3 + imgui.CalcTextSize(hint).X + style.FramePadding().X*2
+	return size
+}
+
+// drawShortcutHint draws hint right-aligned inside the previous item
+func drawShortcutHint(hint string) {
+	padding := imgui.CurrentStyle().FramePadding()
+	hintSize := imgui.CalcTextSize(hint)
+	pos := imgui.Vec2{
+		X: imgui.ItemRectMax().X - padding.X - hintSize.X,
+		Y: (imgui.ItemRectMin().Y + imgui.ItemRectMax().Y - hintSize.Y) / 2,
+	}
+	imgui.WindowDrawList().AddTextVec2(pos, imgui.ColorU32Col(imgui.ColTextDisabled), hint)
+}
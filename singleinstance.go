@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// activationRequest is sent by a secondary instance to the running one
+type activationRequest struct {
+	Args []string `json:"args"`
+}
+
+// singleInstance holds the listener of the primary instance
+type singleInstance struct {
+	listener    net.Listener
+	socketPath  string
+	activations chan []string
+}
+
+// Global single-instance state, nil unless EnsureSingleInstance was called
+var appInstance *singleInstance
+
+// instanceSocketPath returns the local socket used to reach the running
+// instance. It lives in the user's runtime directory, or else in a private
+// per-user directory under the temp dir, so other users can't claim the name.
+func instanceSocketPath(appID string) (string, error) {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, appID+".sock"), nil
+	}
+
+	dir := filepath.Join(os.TempDir(), fmt.Sprintf("%s-%d", appID, os.Getuid()))
+	if err := os.Mkdir(dir, 0o700); err != nil && !errors.Is(err, fs.ErrExist) {
+		return "", err
+	}
+
+	// The directory may predate us; only its owner can chmod it, and a
+	// symlink would point the chmod somewhere else
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", dir)
+	}
+	if err := os.Chmod(dir, 0o700); err != nil {
+		return "", fmt.Errorf("%s is not owned by this user: %w", dir, err)
+	}
+	return filepath.Join(dir, "instance.sock"), nil
+}
+
+// EnsureSingleInstance makes sure only one copy of the application is running.
+// If another instance already owns appID, the command-line arguments (including
+// any file to open) are forwarded to it and false is returned; the caller
+// should exit. Otherwise this process becomes the primary instance and true
+// is returned.
+func EnsureSingleInstance(appID string) (bool, error) {
+	socketPath, err := instanceSocketPath(appID)
+	if err != nil {
+		return false, fmt.Errorf("locating the %s instance socket: %w", appID, err)
+	}
+
+	// Try to reach an already running instance first
+	conn, err := net.Dial("unix", socketPath)
+	if err == nil {
+		defer conn.Close()
+
+		request := activationRequest{Args: forwardedArgs(os.Args[1:])}
+		if err := json.NewEncoder(conn).Encode(request); err != nil {
+			return false, fmt.Errorf("forwarding activation to %s: %w", appID, err)
+		}
+		return false, nil
+	}
+
+	// A refused connection means a leftover socket file from a crashed
+	// instance; any other failure may be a live instance we can't reach
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		os.Remove(socketPath)
+	} else if !errors.Is(err, syscall.ENOENT) {
+		return false, fmt.Errorf("reaching running %s instance: %w", appID, err)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return false, fmt.Errorf("listening for %s instances: %w", appID, err)
+	}
+	if err := os.Chmod(socketPath, 0o600); err != nil {
+		listener.Close()
+		return false, fmt.Errorf("restricting the %s instance socket: %w", appID, err)
+	}
+
+	appInstance = &singleInstance{
+		listener:    listener,
+		socketPath:  socketPath,
+		activations: make(chan []string, 16),
+	}
+	go appInstance.serve()
+
+	return true, nil
+}
+
+// forwardedArgs makes file arguments absolute, since the running instance
+// resolves them against its own working directory
+func forwardedArgs(args []string) []string {
+	forwarded := make([]string, len(args))
+	for i, arg := range args {
+		forwarded[i] = arg
+		if strings.HasPrefix(arg, "-") || strings.Contains(arg, "://") {
+			continue
+		}
+		if _, err := os.Stat(arg); err != nil && !strings.ContainsRune(arg, filepath.Separator) {
+			continue
+		}
+		if abs, err := filepath.Abs(arg); err == nil {
+			forwarded[i] = abs
+		}
+	}
+	return forwarded
+}
+
+// serve accepts activation requests from secondary instances
+func (s *singleInstance) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go func(conn net.Conn) {
+			defer conn.Close()
+
+			var request activationRequest
+			if err := json.NewDecoder(conn).Decode(&request); err != nil {
+				return
+			}
+			s.activations <- request.Args
+		}(conn)
+	}
+}
+
+// close stops listening and removes the socket file
+func (s *singleInstance) close() {
+	s.listener.Close()
+	os.Remove(s.socketPath)
+}
+
+// OnActivate sets the callback invoked on the UI thread when another instance
+// forwards its arguments to this one (builder pattern)
+func (w *MasterWindow) OnActivate(onActivate func(args []string)) *MasterWindow {
+	w.onActivate = onActivate
+	return w
+}
+
+// processActivations handles pending activation requests, raising the window
+func (w *MasterWindow) processActivations() {
+	if appInstance == nil {
+		return
+	}
+
+	for {
+		select {
+		case args := <-appInstance.activations:
+			w.Raise()
+			if w.onActivate != nil {
+				w.onActivate(args)
+			}
+			LogStatus(fmt.Sprintf("Activated by another instance: %v", args))
+		default:
+			return
+		}
+	}
+}
+
+// Raise restores the master window if it is minimized, brings it to the
+// front and gives it input focus. Call it on the UI thread.
+func (w *MasterWindow) Raise() {
+	focusCurrentWindow()
+	w.backend.Refresh()
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"image"
+	"image/draw"
+	"sync/atomic"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/backend"
+	"github.com/AllenDang/cimgui-go/backend/glfwbackend"
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// splashFadeDuration is how long the splash takes to fade into the main UI
+const splashFadeDuration = 400 * time.Millisecond
+
+// SplashScreen shows an image in a borderless window while the app initializes
+type SplashScreen struct {
+	image       image.Image
+	minDuration time.Duration
+	texture     *backend.Texture
+	shownAt     time.Time
+	fadeStart   time.Time
+	done        atomic.Bool
+}
+
+// ShowSplash displays img in a borderless window until Done has been called
+// and at least minDuration has passed, then fades into the main UI.
+// Startup work should run on a goroutine and call Done when finished.
+func (w *MasterWindow) ShowSplash(img image.Image, minDuration time.Duration) *SplashScreen {
+	w.splash = &SplashScreen{
+		image:       img,
+		minDuration: minDuration,
+	}
+
+	bounds := img.Bounds()
+	w.backend.SetWindowFlags(glfwbackend.GLFWWindowFlagsFrameless, 1)
+	w.backend.SetWindowSize(bounds.Dx(), bounds.Dy())
+
+	return w.splash
+}
+
+// Done signals that initialization has finished; safe to call from any goroutine
+func (s *SplashScreen) Done() {
+	s.done.Store(true)
+}
+
+// render draws the splash for this frame and reports whether the main UI
+// should be built underneath it
+func (s *SplashScreen) render(w *MasterWindow) bool {
+	now := time.Now()
+
+	// Textures can only be created once the render loop is running
+	if s.texture == nil {
+		s.texture = backend.NewTextureFromRgba(imageToRGBA(s.image))
+		s.shownAt = now
+	}
+
+	alpha := float32(1.0)
+	if s.fadeStart.IsZero() {
+		if !s.done.Load() || now.Sub(s.shownAt) < s.minDuration {
+			s.draw(alpha)
+			return false
+		}
+
+		// Initialization finished, switch to the real window and start fading
+		w.backend.SetWindowFlags(glfwbackend.GLFWWindowFlagsFrameless, 0)
+		w.backend.SetWindowSize(w.width, w.height)
+		s.fadeStart = now
+	}
+
+	alpha = 1 - float32(now.Sub(s.fadeStart))/float32(splashFadeDuration)
+	if alpha <= 0 {
+		s.texture.Release()
+		w.splash = nil
+		return true
+	}
+
+	s.draw(alpha)
+	return true
+}
+
+// draw paints the splash image centered over the whole viewport
+func (s *SplashScreen) draw(alpha float32) {
+	viewport := imgui.MainViewport()
+	pos := viewport.Pos()
+	size := viewport.Size()
+
+	bounds := s.image.Bounds()
+	imageSize := imgui.Vec2{X: float32(bounds.Dx()), Y: float32(bounds.Dy())}
+	min := imgui.Vec2{X: pos.X + (size.X-imageSize.X)/2, Y: pos.Y + (size.Y-imageSize.Y)/2}
+	max := imgui.Vec2{X: min.X + imageSize.X, Y: min.Y + imageSize.Y}
+
+	drawList := imgui.ForegroundDrawListViewportPtr()
+	background := imgui.StyleColorVec4(imgui.ColWindowBg)
+	drawList.AddRectFilled(pos, imgui.Vec2{X: pos.X + size.X, Y: pos.Y + size.Y},
+		imgui.ColorU32Vec4(imgui.Vec4{X: background.X, Y: background.Y, Z: background.Z, W: alpha}))
+	drawList.AddImageV(s.texture.ID, min, max, imgui.Vec2{}, imgui.Vec2{X: 1, Y: 1},
+		imgui.ColorU32Vec4(imgui.Vec4{X: 1, Y: 1, Z: 1, W: alpha}))
+}
+
+// imageToRGBA converts any image to the RGBA layout expected by textures
+func imageToRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba
+	}
+
+	rgba := image.NewRGBA(img.Bounds())
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	return rgba
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// styleCounts is the depth of the style color and variable stacks, as
+// pushed through the tracked functions below
+type styleCounts struct {
+	colors int32
+	vars   int32
+}
+
+// Style stack tracking state
+var (
+	styleDebug bool
+	styleStack styleCounts
+	styleFloor styleCounts // stack depth when the widget being built started
+)
+
+// SetStyleDebug toggles checking that every widget pops the styles it
+// pushes. Leaks and extra pops are reported as diagnostics and repaired,
+// instead of failing deep inside ImGui. Only pushes made through the
+// functions below are seen; calling imgui.PushStyleColorVec4 and friends
+// directly bypasses the check, and an unbalanced raw call is still left
+// for ImGui's own assertions to catch.
+func SetStyleDebug(enabled bool) {
+	styleDebug = enabled
+}
+
+// PushStyleColor pushes a style color; use it instead of the raw ImGui call
+// so the style debug mode can track it
+func PushStyleColor(colorID imgui.Col, color imgui.Vec4) {
+	imgui.PushStyleColorVec4(colorID, color)
+	styleStack.colors++
+}
+
+// PopStyleColor pops count style colors pushed with PushStyleColor
+func PopStyleColor(count int32) {
+	if styleDebug && styleStack.colors-count < styleFloor.colors {
+		reportStyleMisuse(fmt.Sprintf("popped %d style colors it did not push", styleFloor.colors-(styleStack.colors-count)))
+		count = styleStack.colors - styleFloor.colors
+	}
+	if count > 0 {
+		imgui.PopStyleColorV(count)
+		styleStack.colors -= count
+	}
+}
+
+// PushStyleVar pushes a float style variable; use it instead of the raw
+// ImGui call so the style debug mode can track it
+func PushStyleVar(varID imgui.StyleVar, value float32) {
+	imgui.PushStyleVarFloat(varID, value)
+	styleStack.vars++
+}
+
+// PushStyleVarVec2 pushes a two-component style variable, tracked like PushStyleVar
+func PushStyleVarVec2(varID imgui.StyleVar, value imgui.Vec2) {
+	imgui.PushStyleVarVec2(varID, value)
+	styleStack.vars++
+}
+
+// PopStyleVar pops count style variables pushed with PushStyleVar
+func PopStyleVar(count int32) {
+	if styleDebug && styleStack.vars-count < styleFloor.vars {
+		reportStyleMisuse(fmt.Sprintf("popped %d style vars it did not push", styleFloor.vars-(styleStack.vars-count)))
+		count = styleStack.vars - styleFloor.vars
+	}
+	if count > 0 {
+		imgui.PopStyleVarV(count)
+		styleStack.vars -= count
+	}
+}
+
+// beginStyleCheck records the stack depth before a widget builds; the
+// returned function checks the balance afterwards and must run while the
+// widget is still the one being built
+func beginStyleCheck() func() {
+	before, outerFloor := styleStack, styleFloor
+	styleFloor = styleStack
+
+	return func() {
+		styleFloor = outerFloor
+		if styleStack == before {
+			return
+		}
+
+		// Report the leak, then pop what the widget left behind
+		leaked := styleCounts{colors: styleStack.colors - before.colors, vars: styleStack.vars - before.vars}
+		reportStyleMisuse(fmt.Sprintf("left %d style colors and %d style vars pushed", leaked.colors, leaked.vars))
+
+		if leaked.vars > 0 {
+			imgui.PopStyleVarV(leaked.vars)
+			styleStack.vars -= leaked.vars
+		}
+		if leaked.colors > 0 {
+			imgui.PopStyleColorV(leaked.colors)
+			styleStack.colors -= leaked.colors
+		}
+	}
+}
+
+// reportStyleMisuse reports a style stack problem of the widget being built;
+// the diagnostic carries the widget's ID and path, shown in the log and in
+// DiagnosticsView
+func reportStyleMisuse(problem string) {
+	ReportDiagnostic("style stack: " + problem)
+}
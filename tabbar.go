@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// TabItemWidget is one tab of a TabBar; only the selected tab's layout is built
+type TabItemWidget struct {
+	label      string
+	flags      imgui.TabItemFlags
+	widgets    []Widget
+	layoutFunc func() Layout
+	onClose    func()
+}
+
+// TabItem creates a tab with the given label
+func TabItem(label string) *TabItemWidget {
+	return &TabItemWidget{label: label}
+}
+
+// Flags sets the imgui tab item flags, replacing those set so far (builder pattern)
+func (t *TabItemWidget) Flags(flags imgui.TabItemFlags) *TabItemWidget {
+	t.flags = flags
+	return t
+}
+
+// Unsaved marks the tab with a dot, as for a modified document (builder pattern)
+func (t *TabItemWidget) Unsaved(unsaved bool) *TabItemWidget {
+	if unsaved {
+		t.flags |= imgui.TabItemFlagsUnsavedDocument
+	} else {
+		t.flags &^= imgui.TabItemFlagsUnsavedDocument
+	}
+	return t
+}
+
+// Select makes this the selected tab on this frame; set it for one frame
+// only, e.g. right after opening a document (builder pattern)
+func (t *TabItemWidget) Select() *TabItemWidget {
+	t.flags |= imgui.TabItemFlagsSetSelected
+	return t
+}
+
+// OnClose adds a close button to the tab and sets the callback invoked
+// when it is clicked; the caller removes the tab to close it (builder pattern)
+func (t *TabItemWidget) OnClose(onClose func()) *TabItemWidget {
+	t.onClose = onClose
+	return t
+}
+
+// Layout sets the widgets shown while the tab is selected (builder pattern)
+func (t *TabItemWidget) Layout(widgets ...Widget) *TabItemWidget {
+	t.widgets = widgets
+	return t
+}
+
+// LayoutFunc sets a function creating the tab's widgets, called only while
+// the tab is selected (builder pattern)
+func (t *TabItemWidget) LayoutFunc(layoutFunc func() Layout) *TabItemWidget {
+	t.layoutFunc = layoutFunc
+	return t
+}
+
+// build draws the tab and, when it is selected, its contents. It reports
+// whether the tab is selected.
+func (t *TabItemWidget) build() bool {
+	var selected bool
+	if t.onClose != nil {
+		open := true
+		selected = imgui.BeginTabItemV(t.label, &open, t.flags)
+		if !open {
+			t.onClose()
+		}
+	} else {
+		selected = imgui.BeginTabItemV(t.label, nil, t.flags)
+	}
+
+	if !selected {
+		return false
+	}
+	for _, widget := range t.widgets {
+		buildWidget(widget)
+	}
+	if t.layoutFunc != nil {
+		for _, widget := range t.layoutFunc() {
+			buildWidget(widget)
+		}
+	}
+	imgui.EndTabItem()
+	return true
+}
+
+// tabBarState remembers the selected tab to detect changes
+type tabBarState struct {
+	selected string
+}
+
+func (s *tabBarState) Dispose() {
+	// Nothing to clean up
+}
+
+// TabBarWidget is a row of tabs showing one page at a time
+type TabBarWidget struct {
+	id       string
+	flags    imgui.TabBarFlags
+	items    []*TabItemWidget
+	onSelect func(index int)
+}
+
+// TabBar creates an empty tab bar
+func TabBar(id string) *TabBarWidget {
+	return &TabBarWidget{id: fmt.Sprintf("##tabbar_%s", id)}
+}
+
+// Flags sets the imgui tab bar flags, replacing those set so far (builder pattern)
+func (t *TabBarWidget) Flags(flags imgui.TabBarFlags) *TabBarWidget {
+	t.flags = flags
+	return t
+}
+
+// Reorderable lets the user drag tabs into another order (builder pattern)
+func (t *TabBarWidget) Reorderable() *TabBarWidget {
+	t.flags |= imgui.TabBarFlagsReorderable
+	return t
+}
+
+// TabItems sets the tabs (builder pattern)
+func (t *TabBarWidget) TabItems(items ...*TabItemWidget) *TabBarWidget {
+	t.items = items
+	return t
+}
+
+// OnSelect sets the callback invoked with the index of a newly selected tab (builder pattern)
+func (t *TabBarWidget) OnSelect(onSelect func(index int)) *TabBarWidget {
+	t.onSelect = onSelect
+	return t
+}
+
+func (t *TabBarWidget) getState() *tabBarState {
+	if existingState, exists := GlobalContext.stateMap[t.id]; exists {
+		if state, ok := existingState.(*tabBarState); ok {
+			return state
+		}
+	}
+
+	newState := &tabBarState{}
+	GlobalContext.stateMap[t.id] = newState
+	return newState
+}
+
+func (t *TabBarWidget) Build() {
+	if !imgui.BeginTabBarV(t.id, t.flags) {
+		return
+	}
+
+	state := t.getState()
+	for i, item := range t.items {
+		if !item.build() || state.selected == item.label {
+			continue
+		}
+		// The first selection is the default tab, not a user action
+		previous := state.selected
+		state.selected = item.label
+		if previous != "" && t.onSelect != nil {
+			t.onSelect(i)
+		}
+	}
+	imgui.EndTabBar()
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// TableColumnWidget describes one column of a Table
+type TableColumnWidget struct {
+	label    string
+	flags    imgui.TableColumnFlags
+	width    float32
+	sortable bool
+}
+
+// TableColumn creates a column with a header label
+func TableColumn(label string) *TableColumnWidget {
+	return &TableColumnWidget{label: label}
+}
+
+// Flags sets the imgui column flags, replacing those set so far (builder pattern)
+func (c *TableColumnWidget) Flags(flags imgui.TableColumnFlags) *TableColumnWidget {
+	c.flags = flags
+	return c
+}
+
+// Width gives the column a fixed width in pixels (builder pattern)
+func (c *TableColumnWidget) Width(width float32) *TableColumnWidget {
+	c.width = width
+	c.flags |= imgui.TableColumnFlagsWidthFixed
+	return c
+}
+
+// Sortable lets the user sort by this column by clicking its header (builder pattern)
+func (c *TableColumnWidget) Sortable() *TableColumnWidget {
+	c.sortable = true
+	return c
+}
+
+// DefaultSort makes the table start sorted by this column (builder pattern)
+func (c *TableColumnWidget) DefaultSort(direction SortDirection) *TableColumnWidget {
+	c.sortable = true
+	c.flags |= imgui.TableColumnFlagsDefaultSort
+	if direction == SortDescending {
+		c.flags |= imgui.TableColumnFlagsPreferSortDescending
+	}
+	return c
+}
+
+// TableRowWidget is one row of a Table, with one widget per cell
+type TableRowWidget struct {
+	cells     []Widget
+	minHeight float32
+	bgColor   *imgui.Vec4
+}
+
+// TableRow creates a row from its cells, in column order
+func TableRow(cells ...Widget) *TableRowWidget {
+	return &TableRowWidget{cells: cells}
+}
+
+// MinHeight sets the row's minimum height (builder pattern)
+func (r *TableRowWidget) MinHeight(height float32) *TableRowWidget {
+	r.minHeight = height
+	return r
+}
+
+// BgColor sets the row's background color (builder pattern)
+func (r *TableRowWidget) BgColor(color imgui.Vec4) *TableRowWidget {
+	r.bgColor = &color
+	return r
+}
+
+// build draws the row's cells
+func (r *TableRowWidget) build() {
+	imgui.TableNextRowV(0, r.minHeight)
+	if r.bgColor != nil {
+		imgui.TableSetBgColor(imgui.TableBgTargetRowBg0, imgui.ColorU32Vec4(*r.bgColor))
+	}
+	for _, cell := range r.cells {
+		imgui.TableNextColumn()
+		buildWidget(cell)
+	}
+}
+
+// TableSortSpec is the column the user sorted by
+type TableSortSpec struct {
+	Column    int
+	Direction SortDirection
+}
+
+// TableWidget lays out rows of widgets in columns that can be resized,
+// reordered, hidden and sorted
+type TableWidget struct {
+	id      string
+	flags   imgui.TableFlags
+	width   float32
+	height  float32
+	freeze  int
+	columns []*TableColumnWidget
+	rows    []*TableRowWidget
+	onSort  func(spec TableSortSpec)
+
+	// Rows built on demand instead of rows
+	rowCount   int
+	rowBuilder func(i int) Widget
+}
+
+// Table creates a table with borders, striped rows and resizable columns
+func Table() *TableWidget {
+	return &TableWidget{
+		id:    "##table",
+		flags: imgui.TableFlagsBorders | imgui.TableFlagsRowBg | imgui.TableFlagsResizable,
+	}
+}
+
+// ID sets the table ID; give sibling tables distinct IDs (builder pattern)
+func (t *TableWidget) ID(id string) *TableWidget {
+	t.id = fmt.Sprintf("##table_%s", id)
+	return t
+}
+
+// Flags sets the imgui table flags, replacing the defaults (builder pattern)
+func (t *TableWidget) Flags(flags imgui.TableFlags) *TableWidget {
+	t.flags = flags
+	return t
+}
+
+// Resizable lets the user drag column borders (builder pattern)
+func (t *TableWidget) Resizable(resizable bool) *TableWidget {
+	if resizable {
+		t.flags |= imgui.TableFlagsResizable
+	} else {
+		t.flags &^= imgui.TableFlagsResizable
+	}
+	return t
+}
+
+// Reorderable lets the user drag column headers into another order (builder pattern)
+func (t *TableWidget) Reorderable(reorderable bool) *TableWidget {
+	if reorderable {
+		t.flags |= imgui.TableFlagsReorderable
+	} else {
+		t.flags &^= imgui.TableFlagsReorderable
+	}
+	return t
+}
+
+// Hideable lets the user hide columns from the header's context menu (builder pattern)
+func (t *TableWidget) Hideable(hideable bool) *TableWidget {
+	if hideable {
+		t.flags |= imgui.TableFlagsHideable
+	} else {
+		t.flags &^= imgui.TableFlagsHideable
+	}
+	return t
+}
+
+// Size sets the table's outer size; a height makes the rows scroll under a
+// frozen header. Zero width fills the available space (builder pattern)
+func (t *TableWidget) Size(width, height float32) *TableWidget {
+	t.width = width
+	t.height = height
+	return t
+}
+
+// Freeze keeps the first columns visible while scrolling horizontally (builder pattern)
+func (t *TableWidget) Freeze(columns int) *TableWidget {
+	t.freeze = columns
+	return t
+}
+
+// Columns sets the columns and their headers (builder pattern)
+func (t *TableWidget) Columns(columns ...*TableColumnWidget) *TableWidget {
+	t.columns = columns
+	return t
+}
+
+// Rows sets the rows (builder pattern)
+func (t *TableWidget) Rows(rows ...*TableRowWidget) *TableWidget {
+	t.rows = rows
+	return t
+}
+
+// Virtualized replaces Rows with rowCount rows created by rowBuilder, which
+// is only called for the rows in view. It should return a TableRow; other
+// widgets fill the first cell. Rows must all have the same height, and the
+// table needs a Size height to scroll (builder pattern)
+func (t *TableWidget) Virtualized(rowCount int, rowBuilder func(i int) Widget) *TableWidget {
+	t.rowCount = rowCount
+	t.rowBuilder = rowBuilder
+	return t
+}
+
+// OnSort sets the callback invoked with the sort order when the user
+// sorts by a column, and once at the start for a DefaultSort column. The
+// callback reorders the rows for the next frame (builder pattern)
+func (t *TableWidget) OnSort(onSort func(spec TableSortSpec)) *TableWidget {
+	t.onSort = onSort
+	return t
+}
+
+// columnCount returns the number of columns, from the headers or else the
+// widest row
+func (t *TableWidget) columnCount() int {
+	if len(t.columns) > 0 {
+		return len(t.columns)
+	}
+	count := 0
+	for _, row := range t.rows {
+		count = max(count, len(row.cells))
+	}
+	if count == 0 && t.rowBuilder != nil {
+		return 1
+	}
+	return count
+}
+
+func (t *TableWidget) Build() {
+	columns := t.columnCount()
+	if columns == 0 {
+		return
+	}
+
+	flags := t.flags
+	sortable := false
+	for _, column := range t.columns {
+		sortable = sortable || column.sortable
+	}
+	if sortable {
+		flags |= imgui.TableFlagsSortable
+	}
+	if t.height > 0 {
+		flags |= imgui.TableFlagsScrollY
+	}
+	if t.freeze > 0 {
+		flags |= imgui.TableFlagsScrollX
+	}
+
+	if !imgui.BeginTableV(t.id, int32(columns), flags, imgui.Vec2{X: t.width, Y: t.height}, 0) {
+		return
+	}
+
+	if len(t.columns) > 0 {
+		imgui.TableSetupScrollFreeze(int32(t.freeze), 1)
+		for _, column := range t.columns {
+			columnFlags := column.flags
+			if sortable && !column.sortable {
+				columnFlags |= imgui.TableColumnFlagsNoSort
+			}
+			imgui.TableSetupColumnV(column.label, columnFlags, column.width, 0)
+		}
+		imgui.TableHeadersRow()
+		t.applySort()
+	}
+
+	if t.rowBuilder != nil {
+		buildClipped(t.rowCount, func(i int) {
+			widget := t.rowBuilder(i)
+			row, ok := widget.(*TableRowWidget)
+			if !ok {
+				row = TableRow(widget)
+			}
+			imgui.PushIDInt(int32(i))
+			row.build()
+			imgui.PopID()
+		})
+	} else {
+		for i, row := range t.rows {
+			imgui.PushIDInt(int32(i))
+			row.build()
+			imgui.PopID()
+		}
+	}
+	imgui.EndTable()
+}
+
+// applySort reports a changed sort order to the OnSort callback
+func (t *TableWidget) applySort() {
+	specs := imgui.TableGetSortSpecs()
+	if specs == nil || !specs.SpecsDirty() {
+		return
+	}
+	specs.SetSpecsDirty(false)
+	if t.onSort == nil || specs.SpecsCount() == 0 {
+		return
+	}
+
+	column := specs.Specs()
+	direction := SortAscending
+	if column.SortDirection() == imgui.SortDirectionDescending {
+		direction = SortDescending
+	}
+	t.onSort(TableSortSpec{Column: int(column.ColumnIndex()), Direction: direction})
+}
+
+// buildClipped calls build for the rows of a list that are in view,
+// measuring the height of the first row to skip the others
+func buildClipped(count int, build func(i int)) {
+	clipper := imgui.NewListClipper()
+	clipper.Begin(int32(count))
+	for clipper.Step() {
+		for i := int(clipper.DisplayStart()); i < int(clipper.DisplayEnd()); i++ {
+			build(i)
+		}
+	}
+	clipper.End()
+	clipper.Destroy()
+}
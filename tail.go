@@ -0,0 +1,395 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// tailPollInterval is how often the follower checks the file for new data
+const tailPollInterval = 250 * time.Millisecond
+
+// tailEvent is a batch of lines, or a notice about the file, from the follower
+type tailEvent struct {
+	lines  []string
+	notice string
+	err    error
+}
+
+// followFile reads path like tail -f, sending complete lines on events until
+// done is closed. The last lines of an existing file are sent first. When
+// the file is truncated it starts again from the top, and when it is
+// replaced (rotated) it reopens the new file.
+func followFile(path string, backlog int, events chan<- tailEvent, done <-chan struct{}) {
+	send := func(event tailEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-done:
+			return false
+		}
+	}
+
+	var (
+		file    *os.File
+		info    os.FileInfo
+		reader  *bufio.Reader
+		offset  int64
+		partial string
+		failed  error
+	)
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	open := func(skipToTail bool) bool {
+		opened, err := os.Open(path)
+		if err != nil {
+			if failed == nil || failed.Error() != err.Error() {
+				failed = err
+				return send(tailEvent{err: err})
+			}
+			return true
+		}
+		failed = nil
+
+		file, offset, partial = opened, 0, ""
+		info, _ = file.Stat()
+		if skipToTail && info != nil && backlog > 0 {
+			offset = tailOffset(file, info.Size(), backlog)
+		}
+		file.Seek(offset, io.SeekStart)
+		reader = bufio.NewReader(file)
+		return true
+	}
+
+	ticker := time.NewTicker(tailPollInterval)
+	defer ticker.Stop()
+
+	first := true
+	for {
+		if file == nil {
+			if !open(first) {
+				return
+			}
+			first = false
+		}
+
+		if file != nil {
+			var lines []string
+			for {
+				chunk, err := reader.ReadString('\n')
+				offset += int64(len(chunk))
+				if err != nil {
+					// Keep an unterminated last line until the rest arrives
+					partial += chunk
+					if !errors.Is(err, io.EOF) && !send(tailEvent{err: err}) {
+						return
+					}
+					break
+				}
+				lines = append(lines, strings.TrimRight(partial+chunk, "\r\n"))
+				partial = ""
+			}
+			if len(lines) > 0 && !send(tailEvent{lines: lines}) {
+				return
+			}
+
+			// Detect rotation and truncation by comparing the open file
+			// with whatever is at path now
+			if current, err := os.Stat(path); err == nil {
+				switch {
+				case info != nil && !os.SameFile(info, current):
+					file.Close()
+					file = nil
+					if !send(tailEvent{notice: "file rotated, reopening"}) {
+						return
+					}
+					continue
+				case current.Size() < offset:
+					offset, partial = 0, ""
+					file.Seek(0, io.SeekStart)
+					reader.Reset(file)
+					if !send(tailEvent{notice: "file truncated"}) {
+						return
+					}
+					continue
+				}
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-done:
+			return
+		}
+	}
+}
+
+// tailOffset returns the offset of the start of the last n lines of a file,
+// reading backwards in blocks
+func tailOffset(file *os.File, size int64, n int) int64 {
+	const block = 16 * 1024
+	buffer := make([]byte, block)
+
+	newlines := 0
+	offset := size
+	for offset > 0 {
+		length := min(int64(block), offset)
+		offset -= length
+		if _, err := file.ReadAt(buffer[:length], offset); err != nil && !errors.Is(err, io.EOF) {
+			return 0
+		}
+		for i := length - 1; i >= 0; i-- {
+			if buffer[i] != '\n' {
+				continue
+			}
+			// The newline ending the file does not start a line
+			if offset+i == size-1 {
+				continue
+			}
+			newlines++
+			if newlines == n {
+				return offset + i + 1
+			}
+		}
+	}
+	return 0
+}
+
+// tailLine is one line of the view; notices are shown dimmed
+type tailLine struct {
+	text   string
+	notice bool
+}
+
+// tailState holds the follower and the lines received so far
+type tailState struct {
+	path    string
+	events  chan tailEvent
+	done    chan struct{}
+	lines   []tailLine
+	pending []tailLine // received while paused
+	paused  bool
+	filter  string
+	err     error
+	follow  bool // stay scrolled to the bottom
+}
+
+func (s *tailState) Dispose() {
+	if s.done != nil {
+		close(s.done)
+		s.done = nil
+	}
+}
+
+func (s *tailState) Snapshot() any {
+	snapshot := map[string]any{
+		"path":   s.path,
+		"lines":  len(s.lines),
+		"paused": s.paused,
+		"filter": s.filter,
+	}
+	if s.err != nil {
+		snapshot["error"] = s.err.Error()
+	}
+	return snapshot
+}
+
+// start begins following path on a background goroutine
+func (s *tailState) start(path string, backlog int) {
+	s.Dispose()
+	s.path = path
+	s.lines, s.pending, s.err = nil, nil, nil
+	s.events = make(chan tailEvent, 16)
+	s.done = make(chan struct{})
+	go followFile(path, backlog, s.events, s.done)
+}
+
+// receive takes the events sent since the last frame, keeping at most
+// maxLines lines
+func (s *tailState) receive(maxLines int) {
+	for {
+		select {
+		case event := <-s.events:
+			var lines []tailLine
+			switch {
+			case event.err != nil:
+				s.err = event.err
+				continue
+			case event.notice != "":
+				lines = []tailLine{{text: fmt.Sprintf("-- %s --", event.notice), notice: true}}
+			default:
+				s.err = nil
+				lines = make([]tailLine, len(event.lines))
+				for i, text := range event.lines {
+					lines[i] = tailLine{text: text}
+				}
+			}
+
+			if s.paused {
+				s.pending = trimTailLines(append(s.pending, lines...), maxLines)
+			} else {
+				s.lines = trimTailLines(append(s.lines, lines...), maxLines)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// resume appends the lines held back while paused
+func (s *tailState) resume(maxLines int) {
+	s.paused = false
+	s.lines = trimTailLines(append(s.lines, s.pending...), maxLines)
+	s.pending = nil
+	s.follow = true
+}
+
+// trimTailLines drops the oldest lines beyond maxLines
+func trimTailLines(lines []tailLine, maxLines int) []tailLine {
+	if maxLines > 0 && len(lines) > maxLines {
+		return append(lines[:0], lines[len(lines)-maxLines:]...)
+	}
+	return lines
+}
+
+// TailFileWidget follows a text file like tail -f, with pause and filtering
+type TailFileWidget struct {
+	path     string
+	height   float32
+	maxLines int
+	backlog  int
+}
+
+// TailFile creates a view following the file at path. Changing the path
+// starts following the new file.
+func TailFile(path string) *TailFileWidget {
+	return &TailFileWidget{path: path, maxLines: 10000, backlog: 200}
+}
+
+// Height sets the view height; zero fills the remaining space (builder pattern)
+func (t *TailFileWidget) Height(height float32) *TailFileWidget {
+	t.height = height
+	return t
+}
+
+// MaxLines sets how many lines are kept before the oldest are dropped (builder pattern)
+func (t *TailFileWidget) MaxLines(maxLines int) *TailFileWidget {
+	t.maxLines = maxLines
+	return t
+}
+
+// Backlog sets how many existing lines are shown when the file is opened (builder pattern)
+func (t *TailFileWidget) Backlog(lines int) *TailFileWidget {
+	t.backlog = lines
+	return t
+}
+
+func (t *TailFileWidget) getState() *tailState {
+	id := fmt.Sprintf("##tail_%s", t.path)
+	if existingState, exists := GlobalContext.stateMap[id]; exists {
+		if state, ok := existingState.(*tailState); ok {
+			return state
+		}
+	}
+
+	newState := &tailState{follow: true}
+	GlobalContext.stateMap[id] = newState
+	return newState
+}
+
+func (t *TailFileWidget) Build() {
+	state := t.getState()
+	if state.path != t.path || state.done == nil {
+		state.start(t.path, t.backlog)
+	}
+	state.receive(t.maxLines)
+
+	imgui.PushIDStr(t.path)
+	defer imgui.PopID()
+
+	t.buildToolbar(state)
+
+	if !imgui.BeginChildStrV("##lines", imgui.Vec2{Y: t.height}, imgui.ChildFlagsBorders, imgui.WindowFlagsHorizontalScrollbar) {
+		imgui.EndChild()
+		return
+	}
+
+	matches := state.lines
+	if state.filter != "" {
+		query := strings.ToLower(state.filter)
+		matches = nil
+		for _, line := range state.lines {
+			if line.notice || strings.Contains(strings.ToLower(line.text), query) {
+				matches = append(matches, line)
+			}
+		}
+	}
+
+	highlight := imgui.ColorU32Col(imgui.ColTextSelectedBg)
+	clipper := imgui.NewListClipper()
+	clipper.Begin(int32(len(matches)))
+	for clipper.Step() {
+		for i := clipper.DisplayStart(); i < clipper.DisplayEnd(); i++ {
+			line := matches[i]
+			if line.notice {
+				imgui.TextDisabled(line.text)
+				continue
+			}
+			if state.filter != "" {
+				drawTextHighlights(imgui.WindowDrawList(), imgui.CursorScreenPos(), line.text, MatchRanges(line.text, state.filter), highlight)
+			}
+			imgui.TextUnformatted(line.text)
+		}
+	}
+	clipper.End()
+	clipper.Destroy()
+
+	// Scrolling up stops following; scrolling back to the bottom resumes it
+	if state.follow && !state.paused {
+		imgui.SetScrollHereYV(1)
+	}
+	state.follow = imgui.ScrollY() >= imgui.ScrollMaxY()-1
+
+	imgui.EndChild()
+}
+
+// buildToolbar draws the pause, clear and filter controls and the status
+func (t *TailFileWidget) buildToolbar(state *tailState) {
+	label := "Pause"
+	if state.paused {
+		label = fmt.Sprintf("Resume (%d new)", len(state.pending))
+	}
+	if imgui.SmallButton(label) {
+		if state.paused {
+			state.resume(t.maxLines)
+		} else {
+			state.paused = true
+		}
+	}
+
+	imgui.SameLine()
+	if imgui.SmallButton("Clear") {
+		state.lines, state.pending = nil, nil
+	}
+
+	imgui.SameLine()
+	imgui.SetNextItemWidth(200)
+	imgui.InputTextWithHint("##filter", "Filter", &state.filter, 0, nil)
+
+	imgui.SameLine()
+	if state.err != nil {
+		imgui.TextColored(imgui.Vec4{X: 1, Y: 0.4, Z: 0.4, W: 1}, state.err.Error())
+	} else {
+		imgui.TextDisabled(fmt.Sprintf("%s, %d lines", t.path, len(state.lines)))
+	}
+}
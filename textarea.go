@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// InputTextMultilineWidget is a multi-line text editor
+type InputTextMultilineWidget struct {
+	id       string
+	text     *string
+	width    float32
+	height   float32
+	readOnly bool
+	onChange func()
+}
+
+// InputTextMultiline creates a multi-line editor bound to text
+func InputTextMultiline(label string, text *string) *InputTextMultilineWidget {
+	return &InputTextMultilineWidget{
+		id:   fmt.Sprintf("%s##multiline", label),
+		text: text,
+	}
+}
+
+// Size sets the editor size; zero width fills the available space (builder pattern)
+func (i *InputTextMultilineWidget) Size(width, height float32) *InputTextMultilineWidget {
+	i.width = width
+	i.height = height
+	return i
+}
+
+// ReadOnly lets the text be selected and copied but not edited (builder pattern)
+func (i *InputTextMultilineWidget) ReadOnly(readOnly bool) *InputTextMultilineWidget {
+	i.readOnly = readOnly
+	return i
+}
+
+// OnChange sets the callback invoked when the text is edited (builder pattern)
+func (i *InputTextMultilineWidget) OnChange(onChange func()) *InputTextMultilineWidget {
+	i.onChange = onChange
+	return i
+}
+
+func (i *InputTextMultilineWidget) Build() {
+	if i.text == nil {
+		ReportDiagnostic(fmt.Sprintf("multiline input %q has no bound value", i.id))
+		return
+	}
+
+	defaults := beginDefaults(KindInputText)
+	defer defaults.end()
+
+	width := i.width
+	if width <= 0 {
+		width = defaults.width
+	}
+	if width <= 0 {
+		width = imgui.ContentRegionAvail().X
+	}
+
+	var flags imgui.InputTextFlags
+	if i.readOnly {
+		flags |= imgui.InputTextFlagsReadOnly
+	}
+
+	if imgui.InputTextMultiline(i.id, i.text, imgui.Vec2{X: width, Y: i.height}, flags, nil) && i.onChange != nil {
+		i.onChange()
+	}
+}
+
+// SelectableTextWidget looks like a Label but its text can be selected with
+// the mouse and copied with Ctrl+C
+type SelectableTextWidget struct {
+	text string
+}
+
+// SelectableText creates copyable read-only text
+func SelectableText(text string) *SelectableTextWidget {
+	return &SelectableTextWidget{text: text}
+}
+
+func (s *SelectableTextWidget) Build() {
+	defer beginDefaults(KindLabel).end()
+
+	// A frameless, unpadded read-only input sized to the text draws just
+	// like a label
+	PushStyleVarVec2(imgui.StyleVarFramePadding, imgui.Vec2{})
+	PushStyleVar(imgui.StyleVarFrameBorderSize, 0)
+	PushStyleColor(imgui.ColFrameBg, imgui.Vec4{})
+	defer PopStyleColor(1)
+	defer PopStyleVar(2)
+
+	// The widget never writes to its buffer, so a copy keeps the caller's
+	// string untouched
+	text := s.text
+	size := imgui.CalcTextSize(text).Add(imgui.Vec2{X: 2, Y: 2})
+	flags := imgui.InputTextFlagsReadOnly | imgui.InputTextFlagsNoHorizontalScroll
+	imgui.InputTextMultiline(fmt.Sprintf("##selectable_%s", text), &text, size, flags, nil)
+}
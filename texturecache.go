@@ -0,0 +1,269 @@
+package main
+
+import (
+	"container/list"
+	"fmt"
+	"image"
+
+	"github.com/AllenDang/cimgui-go/backend"
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// textureEntry is one image known to a TextureCache
+type textureEntry struct {
+	key      string
+	texture  *backend.Texture
+	bytes    int64
+	loading  bool
+	err      error
+	lastUsed int32
+	element  *list.Element // position in the LRU list while resident
+}
+
+// decodedImage is a loader result waiting to be uploaded on the UI thread
+type decodedImage struct {
+	key  string
+	rgba *image.RGBA
+	err  error
+}
+
+// TextureStats describes a TextureCache, e.g. for a performance panel
+type TextureStats struct {
+	Resident  int
+	Bytes     int64
+	Budget    int64
+	Pending   int
+	Hits      int
+	Misses    int
+	Uploads   int
+	Evictions int
+}
+
+// TextureCache keeps decoded images on the GPU within a memory budget.
+// Images are decoded on background goroutines and uploaded between frames;
+// the least recently drawn textures are released when over budget.
+type TextureCache struct {
+	budget          int64
+	uploadsPerFrame int
+	entries         map[string]*textureEntry
+	lru             *list.List // resident entries, most recently used first
+	decoded         chan decodedImage
+	done            chan struct{}
+	pending         []decodedImage
+	stats           TextureStats
+
+	// Callbacks waiting for a key to finish loading
+	waiters map[string][]func(texture *backend.Texture, err error)
+}
+
+// textureCaches are the caches whose uploads run before each frame
+var textureCaches []*TextureCache
+
+// NewTextureCache creates a cache holding at most budget bytes of texture
+// memory, counted as 4 bytes per pixel
+func NewTextureCache(budget int64) *TextureCache {
+	c := &TextureCache{
+		budget:          budget,
+		uploadsPerFrame: 4,
+		entries:         make(map[string]*textureEntry),
+		waiters:         make(map[string][]func(texture *backend.Texture, err error)),
+		lru:             list.New(),
+		decoded:         make(chan decodedImage, 64),
+		done:            make(chan struct{}),
+	}
+	textureCaches = append(textureCaches, c)
+	return c
+}
+
+// UploadsPerFrame limits how many textures are uploaded each frame so a
+// burst of loads does not stall rendering (builder pattern)
+func (c *TextureCache) UploadsPerFrame(n int) *TextureCache {
+	c.uploadsPerFrame = n
+	return c
+}
+
+// Get returns the texture for key, or nil while it is loading. On a miss
+// load is called on a background goroutine; it may read from disk or the
+// network. Call Get every frame the image is drawn so it counts as used.
+func (c *TextureCache) Get(key string, load func() (image.Image, error)) *backend.Texture {
+	entry, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		entry = &textureEntry{key: key, loading: true, lastUsed: imgui.FrameCount()}
+		c.entries[key] = entry
+		go func() {
+			img, err := load()
+			result := decodedImage{key: key, err: err}
+			if err == nil {
+				result.rgba = imageToRGBA(img)
+			}
+			select {
+			case c.decoded <- result:
+			case <-c.done:
+			}
+		}()
+		return nil
+	}
+
+	entry.lastUsed = imgui.FrameCount()
+	if entry.texture == nil {
+		return nil
+	}
+	c.stats.Hits++
+	c.lru.MoveToFront(entry.element)
+	return entry.texture
+}
+
+// Load starts loading key like Get and calls onLoaded on the UI thread
+// once the texture is uploaded or has failed; right away if it is already
+// resident. The texture stays resident only while it is drawn, so keep
+// calling Get for it each frame rather than holding on to it.
+func (c *TextureCache) Load(key string, load func() (image.Image, error), onLoaded func(texture *backend.Texture, err error)) {
+	if entry, ok := c.entries[key]; ok && !entry.loading {
+		c.Get(key, load)
+		onLoaded(entry.texture, entry.err)
+		return
+	}
+	c.waiters[key] = append(c.waiters[key], onLoaded)
+	c.Get(key, load)
+}
+
+// notify calls the callbacks waiting for an entry
+func (c *TextureCache) notify(entry *textureEntry) {
+	waiters := c.waiters[entry.key]
+	delete(c.waiters, entry.key)
+	for _, onLoaded := range waiters {
+		onLoaded(entry.texture, entry.err)
+	}
+}
+
+// Err returns why the image for key failed to load, if it did. Failed
+// images are not retried until Forget is called.
+func (c *TextureCache) Err(key string) error {
+	if entry, ok := c.entries[key]; ok {
+		return entry.err
+	}
+	return nil
+}
+
+// Forget releases the texture for key so the next Get loads it again
+func (c *TextureCache) Forget(key string) {
+	entry, ok := c.entries[key]
+	if !ok || entry.loading {
+		return
+	}
+	c.release(entry)
+	delete(c.entries, key)
+}
+
+// Stats returns the cache's current statistics
+func (c *TextureCache) Stats() TextureStats {
+	stats := c.stats
+	stats.Resident = c.lru.Len()
+	stats.Budget = c.budget
+	stats.Pending = len(c.pending)
+	for _, entry := range c.entries {
+		if entry.loading {
+			stats.Pending++
+		}
+	}
+	return stats
+}
+
+// Release frees every texture and stops the cache from uploading
+func (c *TextureCache) Release() {
+	for _, entry := range c.entries {
+		c.release(entry)
+	}
+	clear(c.entries)
+	clear(c.waiters)
+	c.pending = nil
+	close(c.done)
+
+	for i, cache := range textureCaches {
+		if cache == c {
+			textureCaches = append(textureCaches[:i], textureCaches[i+1:]...)
+			break
+		}
+	}
+}
+
+func (c *TextureCache) release(entry *textureEntry) {
+	if entry.texture == nil {
+		return
+	}
+	entry.texture.Release()
+	entry.texture = nil
+	c.lru.Remove(entry.element)
+	entry.element = nil
+	c.stats.Bytes -= entry.bytes
+}
+
+// upload moves finished decodes to the GPU, then evicts down to the budget
+func (c *TextureCache) upload() {
+drain:
+	for {
+		select {
+		case result := <-c.decoded:
+			c.pending = append(c.pending, result)
+		default:
+			break drain
+		}
+	}
+
+	uploads := 0
+	for len(c.pending) > 0 && (c.uploadsPerFrame <= 0 || uploads < c.uploadsPerFrame) {
+		result := c.pending[0]
+		c.pending = c.pending[1:]
+
+		// Forgotten or released while decoding
+		entry, ok := c.entries[result.key]
+		if !ok || !entry.loading {
+			continue
+		}
+		entry.loading = false
+		if result.err != nil {
+			entry.err = fmt.Errorf("loading texture %s: %w", result.key, result.err)
+			c.notify(entry)
+			continue
+		}
+
+		bounds := result.rgba.Bounds()
+		entry.texture = backend.NewTextureFromRgba(result.rgba)
+		entry.bytes = int64(bounds.Dx()) * int64(bounds.Dy()) * 4
+		entry.element = c.lru.PushFront(entry)
+		c.stats.Bytes += entry.bytes
+		c.stats.Uploads++
+		uploads++
+		c.notify(entry)
+	}
+
+	c.evict()
+}
+
+// evict releases least recently used textures until the cache fits its
+// budget. Textures drawn this frame or the last are kept even over budget,
+// since they are on screen.
+func (c *TextureCache) evict() {
+	frame := imgui.FrameCount()
+	for c.budget > 0 && c.stats.Bytes > c.budget {
+		oldest := c.lru.Back()
+		if oldest == nil {
+			return
+		}
+		entry := oldest.Value.(*textureEntry)
+		if frame-entry.lastUsed <= 1 {
+			return
+		}
+		c.release(entry)
+		delete(c.entries, entry.key)
+		c.stats.Evictions++
+	}
+}
+
+// processTextureCaches uploads decoded images; must run on the UI thread
+func processTextureCaches() {
+	for _, cache := range textureCaches {
+		cache.upload()
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// NewTheme creates an empty theme; unset colors and variables keep ImGui's defaults
+func NewTheme(name string) *Theme {
+	return &Theme{
+		name:   name,
+		colors: make(map[int]imgui.Vec4),
+		vars:   make(map[int]float32),
+	}
+}
+
+// Name returns the theme's display name
+func (t *Theme) Name() string {
+	return t.name
+}
+
+// Clone copies the theme under a new name, so a built-in theme can be
+// customized without changing it for everyone
+func (t *Theme) Clone(name string) *Theme {
+	clone := NewTheme(name)
+	for colorID, color := range t.colors {
+		clone.colors[colorID] = color
+	}
+	for varID, value := range t.vars {
+		clone.vars[varID] = value
+	}
+	return clone
+}
+
+// SetColor sets any style color (builder pattern)
+func (t *Theme) SetColor(colorID int, color imgui.Vec4) *Theme {
+	t.colors[colorID] = color
+	return t
+}
+
+// SetVar sets any float style variable (builder pattern)
+func (t *Theme) SetVar(varID int, value float32) *Theme {
+	t.vars[varID] = value
+	return t
+}
+
+// ScrollbarSize sets the scrollbar width (builder pattern)
+func (t *Theme) ScrollbarSize(size float32) *Theme {
+	return t.SetVar(int(imgui.StyleVarScrollbarSize), size)
+}
+
+// ScrollbarRounding sets the scrollbar grab corner radius (builder pattern)
+func (t *Theme) ScrollbarRounding(rounding float32) *Theme {
+	return t.SetVar(int(imgui.StyleVarScrollbarRounding), rounding)
+}
+
+// ScrollbarColors sets the scrollbar track and grab colors (builder pattern)
+func (t *Theme) ScrollbarColors(track, grab, grabHovered, grabActive imgui.Vec4) *Theme {
+	return t.SetColor(int(imgui.ColScrollbarBg), track).
+		SetColor(int(imgui.ColScrollbarGrab), grab).
+		SetColor(int(imgui.ColScrollbarGrabHovered), grabHovered).
+		SetColor(int(imgui.ColScrollbarGrabActive), grabActive)
+}
+
+// GrabMinSize sets the minimum size of slider and scrollbar grabs (builder pattern)
+func (t *Theme) GrabMinSize(size float32) *Theme {
+	return t.SetVar(int(imgui.StyleVarGrabMinSize), size)
+}
+
+// GrabRounding sets the slider grab corner radius (builder pattern)
+func (t *Theme) GrabRounding(rounding float32) *Theme {
+	return t.SetVar(int(imgui.StyleVarGrabRounding), rounding)
+}
+
+// WindowBorderSize sets the border thickness of windows (builder pattern)
+func (t *Theme) WindowBorderSize(size float32) *Theme {
+	return t.SetVar(int(imgui.StyleVarWindowBorderSize), size)
+}
+
+// ChildBorderSize sets the border thickness of child regions (builder pattern)
+func (t *Theme) ChildBorderSize(size float32) *Theme {
+	return t.SetVar(int(imgui.StyleVarChildBorderSize), size)
+}
+
+// PopupBorderSize sets the border thickness of popups and tooltips (builder pattern)
+func (t *Theme) PopupBorderSize(size float32) *Theme {
+	return t.SetVar(int(imgui.StyleVarPopupBorderSize), size)
+}
+
+// FrameBorder toggles borders around buttons, inputs and other framed widgets (builder pattern)
+func (t *Theme) FrameBorder(enabled bool) *Theme {
+	size := float32(0)
+	if enabled {
+		size = 1
+	}
+	return t.SetVar(int(imgui.StyleVarFrameBorderSize), size)
+}
+
+// BorderColor sets the color of all borders (builder pattern)
+func (t *Theme) BorderColor(color imgui.Vec4) *Theme {
+	return t.SetColor(int(imgui.ColBorder), color)
+}
+
+// themeScope counts what a theme pushed so it can be popped again
+type themeScope struct {
+	colors int32
+	vars   int32
+}
+
+// push applies the theme on top of the current style; the returned scope
+// must be popped when the themed content ends
+func (t *Theme) push() themeScope {
+	var scope themeScope
+	for colorID, color := range t.colors {
+		PushStyleColor(imgui.Col(colorID), color)
+		scope.colors++
+	}
+	for varID, value := range t.vars {
+		PushStyleVar(imgui.StyleVar(varID), value)
+		scope.vars++
+	}
+	return scope
+}
+
+// pop restores the style the theme was pushed over
+func (s themeScope) pop() {
+	PopStyleVar(s.vars)
+	PopStyleColor(s.colors)
+}
+
+// Theme renders the window with its own theme on top of the global one (builder pattern)
+func (s *SingleWindowWidget) Theme(theme *Theme) *SingleWindowWidget {
+	s.theme = theme
+	return s
+}
+
+// Theme renders the window with its own theme on top of the global one (builder pattern)
+func (w *WindowWidget) Theme(theme *Theme) *WindowWidget {
+	w.theme = theme
+	return w
+}
+
+// Theme renders the region with its own theme on top of the enclosing one (builder pattern)
+func (c *ChildWidget) Theme(theme *Theme) *ChildWidget {
+	c.theme = theme
+	return c
+}
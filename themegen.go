@@ -0,0 +1,93 @@
+package main
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// GenerateTheme derives a complete, consistent theme from a single accent
+// color. Backgrounds are tinted with the accent hue, and hover/active
+// variants are computed by adjusting the accent's brightness.
+func GenerateTheme(accent imgui.Vec4, dark bool) *Theme {
+	h, s, v := ColorToHSV(accent)
+
+	// In dark themes interaction makes things brighter, in light themes darker
+	shift := float32(0.12)
+	if !dark {
+		shift = -shift
+	}
+	// Unless the accent is already at the limit; clamping would make all
+	// three states the same color, so go the other way instead
+	if v+2*shift > 1 || v+2*shift < 0 {
+		shift = -shift
+	}
+	hovered := HSVA(h, s, v+shift, 1.0)
+	active := HSVA(h, s, v+2*shift, 1.0)
+
+	// Neutral surfaces carry a hint of the accent hue
+	surface := func(value float32) imgui.Vec4 {
+		return HSVA(h, s*0.15, value, 1.0)
+	}
+
+	var background, panel, frame, text, textDisabled, border imgui.Vec4
+	if dark {
+		background = surface(0.08)
+		panel = surface(0.12)
+		frame = surface(0.18)
+		text = surface(0.95)
+		textDisabled = surface(0.50)
+		border = surface(0.30)
+	} else {
+		background = surface(0.95)
+		panel = surface(1.00)
+		frame = surface(0.88)
+		text = surface(0.08)
+		textDisabled = surface(0.55)
+		border = surface(0.70)
+	}
+
+	return &Theme{
+		name: "Generated",
+		colors: map[int]imgui.Vec4{
+			int(imgui.ColWindowBg):             background,
+			int(imgui.ColChildBg):              background,
+			int(imgui.ColPopupBg):              panel,
+			int(imgui.ColMenuBarBg):            panel,
+			int(imgui.ColText):                 text,
+			int(imgui.ColTextDisabled):         textDisabled,
+			int(imgui.ColBorder):               border,
+			int(imgui.ColFrameBg):              frame,
+			int(imgui.ColFrameBgHovered):       WithAlpha(hovered, 0.40),
+			int(imgui.ColFrameBgActive):        WithAlpha(active, 0.60),
+			int(imgui.ColTitleBg):              panel,
+			int(imgui.ColTitleBgActive):        WithAlpha(accent, 0.80),
+			int(imgui.ColTitleBgCollapsed):     panel,
+			int(imgui.ColButton):               WithAlpha(accent, 0.70),
+			int(imgui.ColButtonHovered):        hovered,
+			int(imgui.ColButtonActive):         active,
+			int(imgui.ColHeader):               WithAlpha(accent, 0.45),
+			int(imgui.ColHeaderHovered):        WithAlpha(hovered, 0.70),
+			int(imgui.ColHeaderActive):         active,
+			int(imgui.ColCheckMark):            hovered,
+			int(imgui.ColSliderGrab):           accent,
+			int(imgui.ColSliderGrabActive):     active,
+			int(imgui.ColScrollbarBg):          WithAlpha(background, 0.50),
+			int(imgui.ColScrollbarGrab):        border,
+			int(imgui.ColScrollbarGrabHovered): WithAlpha(hovered, 0.80),
+			int(imgui.ColScrollbarGrabActive):  active,
+			int(imgui.ColSeparator):            border,
+			int(imgui.ColSeparatorHovered):     hovered,
+			int(imgui.ColSeparatorActive):      active,
+			int(imgui.ColTab):                  frame,
+			int(imgui.ColTabHovered):           hovered,
+			int(imgui.ColTabSelected):          WithAlpha(accent, 0.85),
+			int(imgui.ColTextSelectedBg):       WithAlpha(accent, 0.35),
+			int(imgui.ColPlotHistogram):        accent,
+			int(imgui.ColPlotHistogramHovered): hovered,
+		},
+		vars: map[int]float32{
+			int(imgui.StyleVarWindowRounding): 6.0,
+			int(imgui.StyleVarFrameRounding):  4.0,
+			int(imgui.StyleVarGrabRounding):   4.0,
+		},
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// tourTargetRect is the screen rectangle of a tour target
+type tourTargetRect struct {
+	min, max imgui.Vec2
+	frame    int32
+}
+
+// Target rectangles recorded by TourTarget widgets, keyed by target ID
+var tourTargets = make(map[string]tourTargetRect)
+
+// TourTargetWidget marks the previous item as a tour target
+type TourTargetWidget struct {
+	id string
+}
+
+// TourTarget creates a marker that makes the previous item reachable by tour steps
+func TourTarget(id string) *TourTargetWidget {
+	return &TourTargetWidget{id: id}
+}
+
+// Build records the previous item's rectangle for this frame
+func (t *TourTargetWidget) Build() {
+	tourTargets[t.id] = tourTargetRect{
+		min:   imgui.ItemRectMin(),
+		max:   imgui.ItemRectMax(),
+		frame: imgui.FrameCount(),
+	}
+}
+
+// TourStep is a single step of a guided tour
+type TourStep struct {
+	Target string // ID given to TourTarget; empty centers the callout
+	Title  string
+	Text   string
+}
+
+// Tour walks the user through a sequence of highlighted widgets
+type Tour struct {
+	steps    []TourStep
+	current  int
+	active   bool
+	onFinish func()
+	onSkip   func()
+
+	calloutHeight float32 // measured last frame, used to place the callout
+}
+
+// NewTour creates a guided tour from the given steps
+func NewTour(steps ...TourStep) *Tour {
+	return &Tour{steps: steps}
+}
+
+// OnFinish sets the callback for when the last step is completed (builder pattern)
+func (t *Tour) OnFinish(onFinish func()) *Tour {
+	t.onFinish = onFinish
+	return t
+}
+
+// OnSkip sets the callback for when the user skips the tour (builder pattern)
+func (t *Tour) OnSkip(onSkip func()) *Tour {
+	t.onSkip = onSkip
+	return t
+}
+
+// Start shows the tour from its first step
+func (t *Tour) Start() {
+	if len(t.steps) == 0 {
+		return
+	}
+	t.current = 0
+	t.active = true
+}
+
+// IsActive reports whether the tour is currently shown
+func (t *Tour) IsActive() bool {
+	return t.active
+}
+
+func (t *Tour) next() {
+	t.current++
+	if t.current >= len(t.steps) {
+		t.active = false
+		if t.onFinish != nil {
+			t.onFinish()
+		}
+	}
+}
+
+func (t *Tour) skip() {
+	t.active = false
+	if t.onSkip != nil {
+		t.onSkip()
+	}
+}
+
+// Build draws the overlay. It must run after the targets were built this
+// frame, so register it with MasterWindow.AfterFrame or build it last.
+func (t *Tour) Build() {
+	if !t.active {
+		return
+	}
+
+	step := t.steps[t.current]
+	viewport := imgui.MainViewport()
+	screenMin := viewport.Pos()
+	screenMax := screenMin.Add(viewport.Size())
+
+	target, found := tourTargets[step.Target]
+	found = found && target.frame == imgui.FrameCount()
+
+	// The dim layer is a full-screen window so it swallows clicks meant for
+	// the UI underneath; the callout is focused after it and stays on top
+	imgui.SetNextWindowPos(screenMin)
+	imgui.SetNextWindowSize(viewport.Size())
+	imgui.SetNextWindowFocus()
+	imgui.BeginV("##tour_dim", nil, imgui.WindowFlagsNoDecoration|imgui.WindowFlagsNoBackground|
+		imgui.WindowFlagsNoMove|imgui.WindowFlagsNoSavedSettings|imgui.WindowFlagsNoNav)
+
+	drawList := imgui.WindowDrawList()
+	dimColor := imgui.ColorU32Vec4(imgui.Vec4{X: 0, Y: 0, Z: 0, W: 0.6})
+	accent := imgui.ColorU32Vec4(*imgui.StyleColorVec4(imgui.ColButtonHovered))
+
+	var highlightMin, highlightMax imgui.Vec2
+	if found {
+		const padding = 4
+		highlightMin = imgui.Vec2{X: target.min.X - padding, Y: target.min.Y - padding}
+		highlightMax = imgui.Vec2{X: target.max.X + padding, Y: target.max.Y + padding}
+
+		// Dim everything around the target
+		drawList.AddRectFilled(screenMin, imgui.Vec2{X: screenMax.X, Y: highlightMin.Y}, dimColor)
+		drawList.AddRectFilled(imgui.Vec2{X: screenMin.X, Y: highlightMax.Y}, screenMax, dimColor)
+		drawList.AddRectFilled(imgui.Vec2{X: screenMin.X, Y: highlightMin.Y}, imgui.Vec2{X: highlightMin.X, Y: highlightMax.Y}, dimColor)
+		drawList.AddRectFilled(imgui.Vec2{X: highlightMax.X, Y: highlightMin.Y}, imgui.Vec2{X: screenMax.X, Y: highlightMax.Y}, dimColor)
+		drawList.AddRectV(highlightMin, highlightMax, accent, 4.0, imgui.DrawFlagsNone, 2.0)
+	} else {
+		drawList.AddRectFilled(screenMin, screenMax, dimColor)
+	}
+	imgui.End()
+
+	t.drawCallout(found, highlightMin, highlightMax, screenMin, screenMax)
+
+	// Keyboard navigation
+	if !t.active {
+		return
+	}
+	if imgui.IsKeyPressedBoolV(imgui.KeyEnter, false) || imgui.IsKeyPressedBoolV(imgui.KeyRightArrow, false) {
+		t.next()
+	} else if imgui.IsKeyPressedBoolV(imgui.KeyEscape, false) {
+		t.skip()
+	}
+}
+
+const tourCalloutWidth = 280
+
+// drawCallout shows the step text and next/skip buttons in a focused window
+// below the target, or above it when there is no room below
+func (t *Tour) drawCallout(hasTarget bool, targetMin, targetMax, screenMin, screenMax imgui.Vec2) {
+	const margin = 8
+
+	// The height is only known after the window was laid out, so placement
+	// uses last frame's size; the first frame falls back to an estimate
+	height := t.calloutHeight
+	if height == 0 {
+		height = imgui.TextLineHeightWithSpacing()*3 + imgui.FrameHeightWithSpacing()
+	}
+
+	var pos imgui.Vec2
+	if hasTarget {
+		pos = imgui.Vec2{X: targetMin.X, Y: targetMax.Y + margin}
+		if pos.Y+height > screenMax.Y {
+			pos.Y = targetMin.Y - margin - height
+		}
+	} else {
+		pos = imgui.Vec2{
+			X: (screenMin.X+screenMax.X)/2 - tourCalloutWidth/2,
+			Y: (screenMin.Y+screenMax.Y)/2 - height/2,
+		}
+	}
+
+	// Keep the window on screen
+	if pos.Y+height > screenMax.Y {
+		pos.Y = screenMax.Y - height - margin
+	}
+	if pos.Y < screenMin.Y {
+		pos.Y = screenMin.Y + margin
+	}
+	if pos.X+tourCalloutWidth > screenMax.X {
+		pos.X = screenMax.X - tourCalloutWidth - margin
+	}
+	if pos.X < screenMin.X {
+		pos.X = screenMin.X + margin
+	}
+
+	step := t.steps[t.current]
+	imgui.SetNextWindowPos(pos)
+	imgui.SetNextWindowSizeV(imgui.Vec2{X: tourCalloutWidth, Y: 0}, imgui.CondAlways)
+	imgui.SetNextWindowFocus()
+	imgui.BeginV("##tour_callout", nil, imgui.WindowFlagsNoDecoration|imgui.WindowFlagsAlwaysAutoResize|
+		imgui.WindowFlagsNoMove|imgui.WindowFlagsNoSavedSettings)
+
+	imgui.Text(fmt.Sprintf("%s (%d/%d)", step.Title, t.current+1, len(t.steps)))
+	imgui.TextWrapped(step.Text)
+	imgui.Spacing()
+
+	nextLabel := "Next"
+	if t.current == len(t.steps)-1 {
+		nextLabel = "Finish"
+	}
+	skip := imgui.ButtonV("Skip", imgui.Vec2{X: 70, Y: 0})
+	imgui.SameLineV(tourCalloutWidth-imgui.CurrentStyle().WindowPadding().X-70, -1)
+	next := imgui.ButtonV(nextLabel, imgui.Vec2{X: 70, Y: 0})
+
+	t.calloutHeight = imgui.WindowSize().Y
+	imgui.End()
+
+	if next {
+		t.next()
+	} else if skip {
+		t.skip()
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+// Trackable is a value that knows whether it changed since it was last saved
+type Trackable interface {
+	Dirty() bool
+	MarkSaved()
+}
+
+// Tracked holds a value and the value it had when last saved, so it can
+// tell whether there are unsaved changes. Bind widgets to Ptr(); edits
+// made through the pointer are tracked too.
+type Tracked[T comparable] struct {
+	value T
+	saved T
+}
+
+// NewTracked creates a tracked value, treating value as saved
+func NewTracked[T comparable](value T) *Tracked[T] {
+	return &Tracked[T]{value: value, saved: value}
+}
+
+// Get returns the current value
+func (t *Tracked[T]) Get() T {
+	return t.value
+}
+
+// Set changes the current value
+func (t *Tracked[T]) Set(value T) {
+	t.value = value
+}
+
+// Ptr returns a pointer to the current value, for binding to widgets such
+// as InputText or Checkbox
+func (t *Tracked[T]) Ptr() *T {
+	return &t.value
+}
+
+// Saved returns the value as of the last save
+func (t *Tracked[T]) Saved() T {
+	return t.saved
+}
+
+// Dirty reports whether the value differs from the saved one. Changing it
+// back to the saved value makes it clean again.
+func (t *Tracked[T]) Dirty() bool {
+	return t.value != t.saved
+}
+
+// MarkSaved records the current value as saved
+func (t *Tracked[T]) MarkSaved() {
+	t.saved = t.value
+}
+
+// Revert discards unsaved changes
+func (t *Tracked[T]) Revert() {
+	t.value = t.saved
+}
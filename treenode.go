@@ -0,0 +1,160 @@
+package main
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// TreeNodeWidget is an expandable node in a hierarchy. Children are only
+// built while the node is open, so a large tree costs only its expanded
+// part.
+type TreeNodeWidget struct {
+	label      string
+	flags      imgui.TreeNodeFlags
+	widgets    []Widget
+	layoutFunc func() Layout
+	onClick    func()
+}
+
+// TreeNode creates a collapsed tree node
+func TreeNode(label string) *TreeNodeWidget {
+	return &TreeNodeWidget{
+		label: label,
+		flags: imgui.TreeNodeFlagsSpanAvailWidth,
+	}
+}
+
+// Flags sets the imgui tree node flags, replacing those set so far (builder pattern)
+func (t *TreeNodeWidget) Flags(flags imgui.TreeNodeFlags) *TreeNodeWidget {
+	t.flags = flags
+	return t
+}
+
+// DefaultOpen opens the node the first time it is shown (builder pattern)
+func (t *TreeNodeWidget) DefaultOpen() *TreeNodeWidget {
+	t.flags |= imgui.TreeNodeFlagsDefaultOpen
+	return t
+}
+
+// Leaf marks a node without children: no arrow and nothing to expand (builder pattern)
+func (t *TreeNodeWidget) Leaf() *TreeNodeWidget {
+	t.flags |= imgui.TreeNodeFlagsLeaf
+	return t
+}
+
+// Framed draws the node with a background frame, like a header (builder pattern)
+func (t *TreeNodeWidget) Framed() *TreeNodeWidget {
+	t.flags |= imgui.TreeNodeFlagsFramed
+	return t
+}
+
+// Selected highlights the node (builder pattern)
+func (t *TreeNodeWidget) Selected(selected bool) *TreeNodeWidget {
+	if selected {
+		t.flags |= imgui.TreeNodeFlagsSelected
+	} else {
+		t.flags &^= imgui.TreeNodeFlagsSelected
+	}
+	return t
+}
+
+// OnClick sets the callback invoked when the label is clicked. Clicks on
+// the arrow only toggle the node, and the node then opens on double-click
+// instead (builder pattern)
+func (t *TreeNodeWidget) OnClick(onClick func()) *TreeNodeWidget {
+	t.onClick = onClick
+	t.flags |= imgui.TreeNodeFlagsOpenOnArrow | imgui.TreeNodeFlagsOpenOnDoubleClick
+	return t
+}
+
+// Layout sets the children shown while the node is open (builder pattern)
+func (t *TreeNodeWidget) Layout(widgets ...Widget) *TreeNodeWidget {
+	t.widgets = widgets
+	return t
+}
+
+// LayoutFunc sets a function creating the children, called only while the
+// node is open. Use it when creating the children is itself costly, e.g.
+// listing a directory (builder pattern)
+func (t *TreeNodeWidget) LayoutFunc(layoutFunc func() Layout) *TreeNodeWidget {
+	t.layoutFunc = layoutFunc
+	return t
+}
+
+func (t *TreeNodeWidget) Build() {
+	open := imgui.TreeNodeExStrV(t.label, t.flags)
+
+	if t.onClick != nil && imgui.IsItemClicked() && !imgui.IsItemToggledOpen() {
+		t.onClick()
+	}
+
+	if !open || t.flags&imgui.TreeNodeFlagsNoTreePushOnOpen != 0 {
+		return
+	}
+	for _, widget := range t.widgets {
+		buildWidget(widget)
+	}
+	if t.layoutFunc != nil {
+		for _, widget := range t.layoutFunc() {
+			buildWidget(widget)
+		}
+	}
+	imgui.TreePop()
+}
+
+// CollapsingHeaderWidget is a full-width header folding a section of a window
+type CollapsingHeaderWidget struct {
+	label   string
+	flags   imgui.TreeNodeFlags
+	widgets []Widget
+	onClose func()
+}
+
+// CollapsingHeader creates a collapsed section header
+func CollapsingHeader(label string) *CollapsingHeaderWidget {
+	return &CollapsingHeaderWidget{label: label}
+}
+
+// Flags sets the imgui tree node flags, replacing those set so far (builder pattern)
+func (c *CollapsingHeaderWidget) Flags(flags imgui.TreeNodeFlags) *CollapsingHeaderWidget {
+	c.flags = flags
+	return c
+}
+
+// DefaultOpen opens the section the first time it is shown (builder pattern)
+func (c *CollapsingHeaderWidget) DefaultOpen() *CollapsingHeaderWidget {
+	c.flags |= imgui.TreeNodeFlagsDefaultOpen
+	return c
+}
+
+// OnClose adds a close button to the header and sets the callback invoked
+// when it is clicked; the caller stops building the header to hide it (builder pattern)
+func (c *CollapsingHeaderWidget) OnClose(onClose func()) *CollapsingHeaderWidget {
+	c.onClose = onClose
+	return c
+}
+
+// Layout sets the widgets shown while the section is open (builder pattern)
+func (c *CollapsingHeaderWidget) Layout(widgets ...Widget) *CollapsingHeaderWidget {
+	c.widgets = widgets
+	return c
+}
+
+func (c *CollapsingHeaderWidget) Build() {
+	var open bool
+	if c.onClose != nil {
+		visible := true
+		open = imgui.CollapsingHeaderBoolPtrV(c.label, &visible, c.flags)
+		if !visible {
+			c.onClose()
+		}
+	} else {
+		open = imgui.CollapsingHeaderTreeNodeFlagsV(c.label, c.flags)
+	}
+
+	if !open {
+		return
+	}
+	for _, widget := range c.widgets {
+		buildWidget(widget)
+	}
+}
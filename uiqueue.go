@@ -0,0 +1,18 @@
+package main
+
+// uiQueue carries work from background goroutines to the UI thread, where
+// processUIQueue runs it between frames
+var uiQueue = make(chan func(), 64)
+
+// processUIQueue runs the work queued since the last frame; must run on
+// the UI thread
+func processUIQueue() {
+	for {
+		select {
+		case work := <-uiQueue:
+			work()
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,406 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// Release describes a published version of the application
+type Release struct {
+	Version   string    `json:"version"`
+	Notes     string    `json:"notes"`
+	Page      string    `json:"page"`
+	Download  string    `json:"download"`
+	SHA256    string    `json:"sha256"` // hex digest of the download, required to install it
+	Published time.Time `json:"published"`
+}
+
+// UpdateChecker looks for a newer release in the background and offers it
+// in a card in the bottom-right corner, with the release notes and a button
+// that downloads and launches the installer
+type UpdateChecker struct {
+	current   string
+	fetch     func(ctx context.Context) (Release, error)
+	timeout   time.Duration
+	onInstall func()
+	onError   func(err error)
+
+	release     Release
+	showNotes   bool
+	downloading bool
+	received    atomic.Int64
+	total       atomic.Int64
+	err         error
+}
+
+// CheckForUpdates creates an update checker for the running version; set a
+// feed with GitHub or JSONFeed, then call Start
+func CheckForUpdates(currentVersion string) *UpdateChecker {
+	return &UpdateChecker{current: currentVersion, timeout: 15 * time.Second}
+}
+
+// GitHub reads the latest release of a GitHub repository. The installer is
+// the first asset whose name mentions the running OS; its checksum comes
+// from the digest GitHub computes for each asset (builder pattern)
+func (u *UpdateChecker) GitHub(owner, repo string) *UpdateChecker {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", owner, repo)
+	u.fetch = func(ctx context.Context) (Release, error) {
+		var latest struct {
+			TagName     string    `json:"tag_name"`
+			Body        string    `json:"body"`
+			HTMLURL     string    `json:"html_url"`
+			PublishedAt time.Time `json:"published_at"`
+			Assets      []struct {
+				Name   string `json:"name"`
+				URL    string `json:"browser_download_url"`
+				Digest string `json:"digest"`
+			} `json:"assets"`
+		}
+		if err := fetchJSON(ctx, url, &latest); err != nil {
+			return Release{}, err
+		}
+
+		release := Release{
+			Version:   latest.TagName,
+			Notes:     latest.Body,
+			Page:      latest.HTMLURL,
+			Published: latest.PublishedAt,
+		}
+		for _, asset := range latest.Assets {
+			if strings.Contains(strings.ToLower(asset.Name), runtime.GOOS) {
+				release.Download = asset.URL
+				release.SHA256 = strings.TrimPrefix(asset.Digest, "sha256:")
+				break
+			}
+		}
+		return release, nil
+	}
+	return u
+}
+
+// JSONFeed reads the latest release from url, a JSON document with the
+// fields of Release (builder pattern)
+func (u *UpdateChecker) JSONFeed(url string) *UpdateChecker {
+	u.fetch = func(ctx context.Context) (Release, error) {
+		var release Release
+		err := fetchJSON(ctx, url, &release)
+		return release, err
+	}
+	return u
+}
+
+// Timeout sets how long the check may take (builder pattern)
+func (u *UpdateChecker) Timeout(timeout time.Duration) *UpdateChecker {
+	u.timeout = timeout
+	return u
+}
+
+// OnInstall sets the callback invoked once the installer is launched,
+// typically to quit so it can replace the app (builder pattern)
+func (u *UpdateChecker) OnInstall(onInstall func()) *UpdateChecker {
+	u.onInstall = onInstall
+	return u
+}
+
+// OnError sets the callback invoked, on the UI thread, when the check fails.
+// Failures are otherwise silent, since being offline is not worth
+// interrupting the user for (builder pattern)
+func (u *UpdateChecker) OnError(onError func(err error)) *UpdateChecker {
+	u.onError = onError
+	return u
+}
+
+// Start checks the feed in the background and shows the card if a newer
+// version is out
+func (u *UpdateChecker) Start() {
+	if u.fetch == nil {
+		ReportDiagnostic("update checker started without a feed")
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), u.timeout)
+		defer cancel()
+
+		release, err := u.fetch(ctx)
+		uiQueue <- func() {
+			switch {
+			case err != nil:
+				if u.onError != nil {
+					u.onError(fmt.Errorf("checking for updates: %w", err))
+				}
+			case compareVersions(release.Version, u.current) > 0:
+				u.release = release
+				AddOverlay("update", u).
+					Order(900).
+					Interactive(true).
+					Anchor(AnchorScreen(CornerBottomRight).Offset(-12, -12))
+				Announce(fmt.Sprintf("Version %s is available", release.Version), AnnouncePolite)
+			}
+		}
+	}()
+}
+
+// fetchJSON decodes the JSON document at url into v
+func fetchJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding %s: %w", url, err)
+	}
+	return nil
+}
+
+// compareVersions orders versions such as "v1.10.2" the way semver does:
+// numerically, with a pre-release ("1.2.0-beta.1") before its release. A
+// leading "v" and build metadata ("+build.5") are ignored.
+func compareVersions(a, b string) int {
+	parse := func(version string) (core []int, prerelease string) {
+		version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+		if i := strings.IndexByte(version, '+'); i >= 0 {
+			version = version[:i]
+		}
+		if i := strings.IndexByte(version, '-'); i >= 0 {
+			version, prerelease = version[:i], version[i+1:]
+		}
+		for _, field := range strings.Split(version, ".") {
+			n, _ := strconv.Atoi(field)
+			core = append(core, n)
+		}
+		return core, prerelease
+	}
+
+	coreA, preA := parse(a)
+	coreB, preB := parse(b)
+	for i := 0; i < max(len(coreA), len(coreB)); i++ {
+		var x, y int
+		if i < len(coreA) {
+			x = coreA[i]
+		}
+		if i < len(coreB) {
+			y = coreB[i]
+		}
+		if x != y {
+			return cmp.Compare(x, y)
+		}
+	}
+
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+	return comparePrerelease(preA, preB)
+}
+
+// comparePrerelease orders dot-separated pre-release tags per semver:
+// numeric identifiers numerically and below alphanumeric ones, which
+// compare as text, and a shorter tag first when all shared parts are equal
+func comparePrerelease(a, b string) int {
+	fieldsA, fieldsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < min(len(fieldsA), len(fieldsB)); i++ {
+		x, errX := strconv.Atoi(fieldsA[i])
+		y, errY := strconv.Atoi(fieldsB[i])
+		switch {
+		case errX == nil && errY == nil:
+			if x != y {
+				return cmp.Compare(x, y)
+			}
+		case errX == nil:
+			return -1
+		case errY == nil:
+			return 1
+		default:
+			if c := strings.Compare(fieldsA[i], fieldsB[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmp.Compare(len(fieldsA), len(fieldsB))
+}
+
+func (u *UpdateChecker) Build() {
+	PushStyleColor(imgui.ColChildBg, *imgui.StyleColorVec4(imgui.ColPopupBg))
+	defer PopStyleColor(1)
+
+	flags := imgui.ChildFlagsBorders | imgui.ChildFlagsAutoResizeY | imgui.ChildFlagsAlwaysUseWindowPadding
+	if imgui.BeginChildStrV("##update", imgui.Vec2{X: 340}, flags, 0) {
+		imgui.Text(fmt.Sprintf("Version %s is available", u.release.Version))
+		imgui.TextDisabled(fmt.Sprintf("You have %s", u.current))
+
+		if u.release.Notes != "" {
+			imgui.Checkbox("Release notes", &u.showNotes)
+			if u.showNotes {
+				imgui.TextWrapped(u.release.Notes)
+			}
+		}
+		if u.release.Page != "" {
+			imgui.TextLinkOpenURLV("Open release page", u.release.Page)
+		}
+
+		if u.err != nil {
+			imgui.TextColored(imgui.Vec4{X: 1, Y: 0.4, Z: 0.4, W: 1}, u.err.Error())
+		}
+
+		if u.downloading {
+			fraction := float32(0)
+			if total := u.total.Load(); total > 0 {
+				fraction = float32(u.received.Load()) / float32(total)
+			}
+			imgui.ProgressBarV(fraction, imgui.Vec2{X: -1}, fmt.Sprintf("Downloading %s", formatSize(u.received.Load())))
+		} else {
+			if u.release.Download != "" {
+				if imgui.Button("Download and Install") {
+					u.install()
+				}
+				imgui.SameLine()
+			}
+			if imgui.Button("Later") {
+				RemoveOverlay("update")
+			}
+		}
+	}
+	imgui.EndChild()
+}
+
+// install downloads the installer to the temp directory and launches it
+func (u *UpdateChecker) install() {
+	u.downloading = true
+	u.err = nil
+	u.received.Store(0)
+	u.total.Store(0)
+
+	url, checksum := u.release.Download, u.release.SHA256
+	go func() {
+		installer, err := u.download(url, checksum)
+		if err == nil {
+			err = launchInstaller(installer)
+		}
+		uiQueue <- func() {
+			u.downloading = false
+			if err != nil {
+				u.err = fmt.Errorf("installing update: %w", err)
+				return
+			}
+			RemoveOverlay("update")
+			if u.onInstall != nil {
+				u.onInstall()
+			}
+		}
+	}()
+}
+
+// errNoChecksum is returned for releases that cannot be verified
+var errNoChecksum = errors.New("the release has no SHA-256 checksum to verify the download against; install it from the release page")
+
+// download saves url to a new private temp directory, counting progress,
+// and checks it against the hex SHA-256 checksum
+func (u *UpdateChecker) download(url, checksum string) (string, error) {
+	want, err := hex.DecodeString(strings.TrimSpace(checksum))
+	if err != nil || len(want) != sha256.Size {
+		return "", errNoChecksum
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading %s: %s", url, resp.Status)
+	}
+	u.total.Store(resp.ContentLength)
+
+	// Only this user can reach the directory, and O_EXCL refuses anything
+	// already at the path, so the file run is the file downloaded
+	dir, err := os.MkdirTemp("", "update-")
+	if err != nil {
+		return "", err
+	}
+	name := path.Base(resp.Request.URL.Path)
+	if name == "." || name == "/" {
+		name = "installer"
+	}
+	target := filepath.Join(dir, name)
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o700)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(file, hash), io.TeeReader(resp.Body, progressWriter{&u.received}))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && !bytes.Equal(hash.Sum(nil), want) {
+		err = fmt.Errorf("downloaded file does not match the release checksum")
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return target, nil
+}
+
+// progressWriter counts the bytes written through it
+type progressWriter struct {
+	count *atomic.Int64
+}
+
+func (p progressWriter) Write(b []byte) (int, error) {
+	p.count.Add(int64(len(b)))
+	return len(b), nil
+}
+
+// launchInstaller opens the installer the way the OS would on double-click
+func launchInstaller(installer string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("cmd", "/c", "start", "", installer)
+	case "darwin":
+		cmd = exec.Command("open", installer)
+	default:
+		cmd = exec.Command(installer)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Don't wait: the installer outlives the app
+	return cmd.Process.Release()
+}
@@ -0,0 +1,39 @@
+package main
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2", "1.2.0", 0},
+		{"1.10.0", "1.9.9", 1},
+		{"1.2.3", "1.3", -1},
+		{"2", "1.99.99", 1},
+		{" v2.0.0 ", "2.0.0", 0},
+		{"1.2.0-beta.1", "1.2.0", -1},
+		{"v1.2.0-rc.1", "1.1.9", 1},
+		{"1.2.0-alpha", "1.2.0-alpha.1", -1},
+		{"1.2.0-alpha.1", "1.2.0-alpha.beta", -1},
+		{"1.2.0-alpha.2", "1.2.0-alpha.10", -1},
+		{"1.2.0-beta", "1.2.0-alpha", 1},
+		{"1.2.0-rc.1", "1.2.0-rc.1", 0},
+		{"1.2.0+build.5", "1.2.0", 0},
+		{"1.2.0+build.5", "1.2.1", -1},
+		{"", "0.0.1", -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+"_vs_"+test.b, func(t *testing.T) {
+			if got := compareVersions(test.a, test.b); got != test.want {
+				t.Errorf("compareVersions(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+			}
+			if got := compareVersions(test.b, test.a); got != -test.want {
+				t.Errorf("compareVersions(%q, %q) = %d, want %d", test.b, test.a, got, -test.want)
+			}
+		})
+	}
+}
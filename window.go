@@ -0,0 +1,131 @@
+package main
+
+import (
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// WindowWidget is a movable, resizable window inside the master window.
+// Several can be shown side by side, unlike SingleWindow.
+type WindowWidget struct {
+	title   string
+	pos     *imgui.Vec2
+	size    *imgui.Vec2
+	flags   imgui.WindowFlags
+	open    *bool
+	widgets []Widget
+	raise   bool
+	theme   *Theme
+}
+
+// focusedWindow is the title of the Window that had keyboard focus when
+// last built, or empty
+var focusedWindow string
+
+// FocusedWindow returns the title of the Window that has keyboard focus,
+// or "" if none of them does
+func FocusedWindow() string {
+	return focusedWindow
+}
+
+// Window creates a window; the title is also its ID, so hide anything
+// after "##" to tell windows with the same title apart
+func Window(title string) *WindowWidget {
+	return &WindowWidget{title: title}
+}
+
+// Pos sets where the window first appears; the user can move it afterwards
+// (builder pattern)
+func (w *WindowWidget) Pos(x, y float32) *WindowWidget {
+	w.pos = &imgui.Vec2{X: x, Y: y}
+	return w
+}
+
+// Size sets the window's initial size; the user can resize it afterwards
+// (builder pattern)
+func (w *WindowWidget) Size(width, height float32) *WindowWidget {
+	w.size = &imgui.Vec2{X: width, Y: height}
+	return w
+}
+
+// Flags sets the imgui window flags (builder pattern)
+func (w *WindowWidget) Flags(flags imgui.WindowFlags) *WindowWidget {
+	w.flags = flags
+	return w
+}
+
+// IsOpen adds a close button to the title bar, which sets *open to false.
+// The window is hidden while *open is false (builder pattern)
+func (w *WindowWidget) IsOpen(open *bool) *WindowWidget {
+	w.open = open
+	return w
+}
+
+// BringToFront raises and focuses the window as it is built this frame;
+// set it for one frame only, e.g. after opening the window (builder pattern)
+func (w *WindowWidget) BringToFront() *WindowWidget {
+	w.raise = true
+	return w
+}
+
+// Focus gives the window keyboard focus and raises it, e.g. from a
+// callback elsewhere in the interface
+func (w *WindowWidget) Focus() {
+	imgui.SetWindowFocusStr(w.title)
+}
+
+// IsFocused reports whether the window had keyboard focus when last built
+func (w *WindowWidget) IsFocused() bool {
+	return focusedWindow == w.title
+}
+
+// Layout sets the window's contents (builder pattern)
+func (w *WindowWidget) Layout(widgets ...Widget) *WindowWidget {
+	w.widgets = widgets
+	return w
+}
+
+func (w *WindowWidget) Build() {
+	if w.open != nil && !*w.open {
+		if focusedWindow == w.title {
+			focusedWindow = ""
+		}
+		return
+	}
+
+	if w.pos != nil {
+		imgui.SetNextWindowPosV(*w.pos, imgui.CondFirstUseEver, imgui.Vec2{})
+	}
+	if w.size != nil {
+		imgui.SetNextWindowSizeV(*w.size, imgui.CondFirstUseEver)
+	}
+	if w.raise {
+		imgui.SetNextWindowFocus()
+	}
+
+	flags := w.flags
+	for _, widget := range w.widgets {
+		if _, ok := widget.(*MenuBarWidget); ok {
+			flags |= imgui.WindowFlagsMenuBar
+		}
+	}
+
+	// The window background is read at Begin, so the theme wraps the whole window
+	var scope themeScope
+	if w.theme != nil {
+		scope = w.theme.push()
+	}
+
+	// End is needed even when the window is collapsed
+	if imgui.BeginV(w.title, w.open, flags) {
+		if imgui.IsWindowFocused() {
+			focusedWindow = w.title
+		} else if focusedWindow == w.title {
+			focusedWindow = ""
+		}
+		for _, widget := range w.widgets {
+			buildWidget(widget)
+		}
+	}
+	imgui.End()
+	scope.pop()
+}
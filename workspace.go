@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/AllenDang/cimgui-go/imgui"
+)
+
+// workspacePart is one piece of app state saved in a workspace
+type workspacePart struct {
+	name    string
+	save    func() (any, error)
+	restore func(data json.RawMessage) error
+}
+
+// workspaceFile is the on-disk form of a workspace
+type workspaceFile struct {
+	Saved  time.Time                  `json:"saved"`
+	Layout string                     `json:"layout"`
+	Parts  map[string]json.RawMessage `json:"parts"`
+}
+
+// Workspace snapshots a session: the window layout plus whatever the app
+// registers, such as the open documents and tabs, so it can be restored on
+// the next launch or from a recent-workspaces menu
+type Workspace struct {
+	parts      []workspacePart
+	recentPath string
+	maxRecent  int
+	recent     []string
+	onRestore  func(path string)
+}
+
+// NewWorkspace creates a workspace with nothing registered
+func NewWorkspace() *Workspace {
+	return &Workspace{maxRecent: 10}
+}
+
+// Register adds a piece of state under name. save returns a value to
+// encode as JSON; restore receives that JSON back. Parts are restored in
+// registration order (builder pattern)
+func (w *Workspace) Register(name string, save func() (any, error), restore func(data json.RawMessage) error) *Workspace {
+	w.parts = append(w.parts, workspacePart{name: name, save: save, restore: restore})
+	return w
+}
+
+// RecentFile keeps the list of recent workspaces in path, holding at most
+// limit entries, and loads it now (builder pattern)
+func (w *Workspace) RecentFile(path string, limit int) *Workspace {
+	w.recentPath = path
+	w.maxRecent = limit
+	w.recent = nil
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &w.recent); err != nil {
+			LogWarning(fmt.Sprintf("reading recent workspaces: %v", err))
+		}
+	}
+	return w
+}
+
+// OnRestore sets the callback invoked after a workspace is restored from
+// the recent menu, e.g. to update the title bar (builder pattern)
+func (w *Workspace) OnRestore(onRestore func(path string)) *Workspace {
+	w.onRestore = onRestore
+	return w
+}
+
+// Recent returns the recent workspaces, most recent first
+func (w *Workspace) Recent() []string {
+	return w.recent
+}
+
+// Save writes the layout and every registered part to path
+func (w *Workspace) Save(path string) error {
+	file := workspaceFile{
+		Saved:  time.Now(),
+		Layout: imgui.SaveIniSettingsToMemory(),
+		Parts:  make(map[string]json.RawMessage),
+	}
+	for _, part := range w.parts {
+		value, err := part.save()
+		if err != nil {
+			return fmt.Errorf("saving workspace %s: %w", part.name, err)
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("saving workspace %s: %w", part.name, err)
+		}
+		file.Parts[part.name] = data
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("saving workspace: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("saving workspace: %w", err)
+	}
+	w.remember(path)
+	return nil
+}
+
+// Load restores a workspace saved by Save. The layout only places windows
+// not yet shown, so load before the first frame for a full restore. Parts
+// missing from the file are skipped; the errors of the others are joined.
+func (w *Workspace) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("loading workspace: %w", err)
+	}
+	var file workspaceFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("loading workspace %s: %w", path, err)
+	}
+
+	if file.Layout != "" {
+		imgui.LoadIniSettingsFromMemory(file.Layout)
+	}
+
+	var errs []error
+	for _, part := range w.parts {
+		data, ok := file.Parts[part.name]
+		if !ok {
+			continue
+		}
+		if err := part.restore(data); err != nil {
+			errs = append(errs, fmt.Errorf("restoring workspace %s: %w", part.name, err))
+		}
+	}
+	w.remember(path)
+	return errors.Join(errs...)
+}
+
+// remember moves path to the front of the recent list and saves the list
+func (w *Workspace) remember(path string) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	w.recent = slices.DeleteFunc(w.recent, func(p string) bool { return p == path })
+	w.recent = append([]string{path}, w.recent...)
+	if w.maxRecent > 0 && len(w.recent) > w.maxRecent {
+		w.recent = w.recent[:w.maxRecent]
+	}
+
+	if w.recentPath == "" {
+		return
+	}
+	data, err := json.MarshalIndent(w.recent, "", "  ")
+	if err == nil {
+		err = os.WriteFile(w.recentPath, data, 0o644)
+	}
+	if err != nil {
+		LogWarning(fmt.Sprintf("saving recent workspaces: %v", err))
+	}
+}
+
+// RecentMenu returns a submenu listing the recent workspaces; choosing one
+// loads it
+func (w *Workspace) RecentMenu(label string) *MenuWidget {
+	items := make([]Widget, 0, len(w.recent))
+	for _, path := range w.recent {
+		items = append(items, MenuItem(fmt.Sprintf("%s##%s", filepath.Base(path), path)).OnClick(func() {
+			if err := w.Load(path); err != nil {
+				LogError(err.Error())
+				return
+			}
+			if w.onRestore != nil {
+				w.onRestore(path)
+			}
+		}))
+	}
+	return Menu(label).Enabled(len(items) > 0).Layout(items...)
+}